@@ -0,0 +1,172 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+const (
+	bookmarksURL              = "https://twitter.com/i/api/graphql/j5KExFXtSWj8HjRui17ydA/Bookmarks"
+	bookmarkFolderTimelineURL = "https://twitter.com/i/api/graphql/13H7EUATwethsj-XxX5ohw/BookmarkFolderTimeline"
+	bookmarkFoldersURL        = "https://twitter.com/i/api/graphql/i78YDd0Tza-dV4SYs58kRg/BookmarkFoldersSlice"
+)
+
+// BookmarkFolder of the logged in account.
+type BookmarkFolder struct {
+	ID   string
+	Name string
+}
+
+type bookmarksTimeline struct {
+	Data struct {
+		BookmarkTimelineV2 struct {
+			Timeline struct {
+				Instructions []instruction `json:"instructions"`
+			} `json:"timeline"`
+		} `json:"bookmark_timeline_v2"`
+		BookmarkCollectionTimeline struct {
+			Timeline struct {
+				Instructions []instruction `json:"instructions"`
+			} `json:"timeline"`
+		} `json:"bookmark_collection_timeline"`
+	} `json:"data"`
+}
+
+type bookmarkFolders struct {
+	Data struct {
+		Viewer struct {
+			UserResults struct {
+				Result struct {
+					BookmarkCollectionsSlice struct {
+						Items []struct {
+							ID   string `json:"id"`
+							Name string `json:"name"`
+						} `json:"items"`
+						SliceInfo struct {
+							NextCursor string `json:"next_cursor"`
+						} `json:"slice_info"`
+					} `json:"bookmark_collections_slice"`
+				} `json:"result"`
+			} `json:"user_results"`
+		} `json:"viewer"`
+	} `json:"data"`
+}
+
+// GetBookmarks returns channel with bookmarked tweets of the logged in account.
+func (s *Scraper) GetBookmarks(ctx context.Context, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, "", maxTweetsNbr, timelineFilter{}, func(_ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.FetchBookmarks(maxTweetsNbr, cursor)
+	})
+}
+
+// GetBookmarkFolderTweets returns channel with tweets from a bookmark folder of the logged in account.
+func (s *Scraper) GetBookmarkFolderTweets(ctx context.Context, folderID string, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, folderID, maxTweetsNbr, timelineFilter{}, s.FetchBookmarkFolderTweets)
+}
+
+// FetchBookmarks gets bookmarked tweets of the logged in account, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchBookmarks(maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	if maxTweetsNbr > 100 {
+		maxTweetsNbr = 100
+	}
+
+	variables := map[string]interface{}{
+		"count":                  maxTweetsNbr,
+		"includePromotedContent": false,
+	}
+
+	timeline, err := s.getBookmarksTimeline(bookmarksURL, variables, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	tweets, nextCursor := parseTimelineTweets(timeline.Data.BookmarkTimelineV2.Timeline.Instructions, false)
+	return tweets, nextCursor, nil
+}
+
+// FetchBookmarkFolderTweets gets tweets from a bookmark folder, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchBookmarkFolderTweets(folderID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	variables := map[string]interface{}{
+		"bookmark_collection_id": folderID,
+		"includePromotedContent": false,
+	}
+
+	timeline, err := s.getBookmarksTimeline(bookmarkFolderTimelineURL, variables, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	tweets, nextCursor := parseTimelineTweets(timeline.Data.BookmarkCollectionTimeline.Timeline.Instructions, false)
+	return tweets, nextCursor, nil
+}
+
+func (s *Scraper) getBookmarksTimeline(endpoint string, variables map[string]interface{}, cursor string) (*bookmarksTimeline, error) {
+	if !s.isLogged || s.isOpenAccount {
+		return nil, errors.New("scraper is not logged in for bookmarks")
+	}
+
+	req, err := s.newRequest("GET", endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	features := timelineFeatures()
+	features["graphql_timeline_v2_bookmark_timeline"] = true
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(features))
+	req.URL.RawQuery = query.Encode()
+
+	var timeline bookmarksTimeline
+	err = s.RequestAPI(req, &timeline)
+	if err != nil {
+		return nil, err
+	}
+	return &timeline, nil
+}
+
+// GetBookmarkFolders return list of bookmark folders of the logged in account.
+// Folders are available only for Twitter Blue subscribers.
+func (s *Scraper) GetBookmarkFolders() ([]BookmarkFolder, error) {
+	if !s.isLogged || s.isOpenAccount {
+		return nil, errors.New("scraper is not logged in for bookmarks")
+	}
+
+	var folders []BookmarkFolder
+	var cursor string
+	for {
+		req, err := s.newRequest("GET", bookmarkFoldersURL)
+		if err != nil {
+			return nil, err
+		}
+
+		variables := map[string]interface{}{}
+		if cursor != "" {
+			variables["cursor"] = cursor
+		}
+
+		query := url.Values{}
+		query.Set("variables", mapToJSONString(variables))
+		req.URL.RawQuery = query.Encode()
+
+		var jsn bookmarkFolders
+		err = s.RequestAPI(req, &jsn)
+		if err != nil {
+			return nil, err
+		}
+
+		slice := jsn.Data.Viewer.UserResults.Result.BookmarkCollectionsSlice
+		for _, item := range slice.Items {
+			folders = append(folders, BookmarkFolder{ID: item.ID, Name: item.Name})
+		}
+		if len(slice.Items) == 0 || slice.SliceInfo.NextCursor == "" || slice.SliceInfo.NextCursor == cursor {
+			break
+		}
+		cursor = slice.SliceInfo.NextCursor
+	}
+	return folders, nil
+}
@@ -0,0 +1,45 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetBookmarks(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	dupcheck := make(map[string]bool)
+	for tweet := range testScraper.GetBookmarks(context.Background(), 20) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if tweet.ID == "" {
+			t.Error("Expected tweet ID is empty")
+		} else if dupcheck[tweet.ID] {
+			t.Errorf("Detect duplicated tweet ID: %s", tweet.ID)
+		} else {
+			dupcheck[tweet.ID] = true
+		}
+	}
+}
+
+func TestGetBookmarkFolders(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	folders, err := testScraper.GetBookmarkFolders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, folder := range folders {
+		if folder.ID == "" {
+			t.Error("Expected folder ID is empty")
+		}
+		for tweet := range testScraper.GetBookmarkFolderTweets(context.Background(), folder.ID, 20) {
+			if tweet.Error != nil {
+				t.Fatal(tweet.Error)
+			}
+		}
+	}
+}
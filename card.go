@@ -0,0 +1,139 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	bindingValue struct {
+		Type         string `json:"type"`
+		BooleanValue bool   `json:"boolean_value"`
+		StringValue  string `json:"string_value"`
+		ImageValue   struct {
+			URL    string `json:"url"`
+			Width  int    `json:"width"`
+			Height int    `json:"height"`
+		} `json:"image_value"`
+	}
+
+	// legacy API returns binding values as object, GraphQL API as array of key-value pairs
+	bindingValues map[string]bindingValue
+
+	legacyCard struct {
+		Name          string        `json:"name"`
+		URL           string        `json:"url"`
+		BindingValues bindingValues `json:"binding_values"`
+	}
+)
+
+func (values *bindingValues) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '[' {
+		var pairs []struct {
+			Key   string       `json:"key"`
+			Value bindingValue `json:"value"`
+		}
+		if err := json.Unmarshal(data, &pairs); err != nil {
+			return err
+		}
+		*values = make(bindingValues, len(pairs))
+		for _, pair := range pairs {
+			(*values)[pair.Key] = pair.Value
+		}
+		return nil
+	}
+	var m map[string]bindingValue
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*values = m
+	return nil
+}
+
+func (values bindingValues) get(keys ...string) string {
+	for _, key := range keys {
+		if value, ok := values[key]; ok && value.StringValue != "" {
+			return value.StringValue
+		}
+	}
+	return ""
+}
+
+func (values bindingValues) image(keys ...string) string {
+	for _, key := range keys {
+		if value, ok := values[key]; ok && value.ImageValue.URL != "" {
+			return value.ImageValue.URL
+		}
+	}
+	return ""
+}
+
+// parseCard fills Poll or Card of the tweet from card binding values
+func parseCard(card *legacyCard, tweet *legacyTweet, tw *Tweet) {
+	if len(card.BindingValues) == 0 {
+		return
+	}
+	values := card.BindingValues
+
+	// GraphQL API may prefix card name with ID, e.g. "2586390716:poll2choice_text_only"
+	name := card.Name
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	if strings.HasPrefix(name, "poll") && strings.Contains(name, "choice") {
+		poll := &Poll{
+			CountsAreFinal: values["counts_are_final"].BooleanValue,
+		}
+		for i := 1; i <= 4; i++ {
+			label := values.get("choice" + strconv.Itoa(i) + "_label")
+			if label == "" {
+				break
+			}
+			count, _ := strconv.Atoi(values.get("choice" + strconv.Itoa(i) + "_count"))
+			poll.Choices = append(poll.Choices, PollChoice{Label: label, Count: count})
+			poll.TotalVotes += count
+		}
+		if tm, err := time.Parse(time.RFC3339, values.get("end_datetime_utc")); err == nil {
+			poll.EndTime = tm
+		}
+		if len(poll.Choices) > 0 {
+			tw.Poll = poll
+		}
+		return
+	}
+
+	title := values.get("title")
+	if title == "" {
+		return
+	}
+	c := &Card{
+		Description: values.get("description"),
+		Domain:      values.get("domain", "vanity_url"),
+		Name:        name,
+		PlayerURL:   values.get("player_url"),
+		Thumbnail: values.image(
+			"thumbnail_image_original",
+			"summary_photo_image_original",
+			"photo_image_full_size_original",
+			"player_image_original",
+			"thumbnail_image_large",
+			"thumbnail_image",
+		),
+		Title: title,
+		URL:   values.get("card_url"),
+	}
+	if c.URL == "" {
+		c.URL = card.URL
+	}
+	// resolve t.co link of the card to target URL
+	for _, url := range tweet.Entities.URLs {
+		if url.URL == c.URL {
+			c.URL = url.ExpandedURL
+			break
+		}
+	}
+	tw.Card = c
+}
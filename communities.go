@@ -0,0 +1,245 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+const (
+	communityURL        = "https://twitter.com/i/api/graphql/lUBKrilodgg9Nikaw3cIiA/CommunityQuery"
+	communityTweetsURL  = "https://twitter.com/i/api/graphql/mhwSsmub4JZgHcs0dtsjrw/CommunityTweetsTimeline"
+	communityMembersURL = "https://twitter.com/i/api/graphql/KDAssJ5lafCy-asH4wm1dw/membersSliceTimeline_Query"
+)
+
+// CommunityRanking type
+type CommunityRanking int
+
+const (
+	// CommunityTop - top tweets of community
+	CommunityTop CommunityRanking = iota
+	// CommunityLatest - latest tweets of community
+	CommunityLatest
+)
+
+type (
+	// Community type.
+	Community struct {
+		Admins      []Profile
+		Description string
+		ID          string
+		MemberCount int
+		Name        string
+		Rules       []CommunityRule
+		URL         string
+	}
+
+	// CommunityRule type.
+	CommunityRule struct {
+		ID          string
+		Name        string
+		Description string
+	}
+
+	communityInfo struct {
+		Typename     string `json:"__typename"`
+		AdminResults struct {
+			Result userResult `json:"result"`
+		} `json:"admin_results"`
+		CreatorResults struct {
+			Result userResult `json:"result"`
+		} `json:"creator_results"`
+		Description string `json:"description"`
+		IDStr       string `json:"id_str"`
+		MemberCount int    `json:"member_count"`
+		Name        string `json:"name"`
+		Rules       []struct {
+			RestID      string `json:"rest_id"`
+			Name        string `json:"name"`
+			Description string `json:"description"`
+		} `json:"rules"`
+	}
+)
+
+func (community *communityInfo) parse() *Community {
+	if community.IDStr == "" {
+		return nil
+	}
+	c := &Community{
+		Description: community.Description,
+		ID:          community.IDStr,
+		MemberCount: community.MemberCount,
+		Name:        community.Name,
+		URL:         "https://twitter.com/i/communities/" + community.IDStr,
+	}
+	for _, admin := range []userResult{community.AdminResults.Result, community.CreatorResults.Result} {
+		profile := admin.parse()
+		if profile.UserID == "" {
+			continue
+		}
+		duplicate := false
+		for _, a := range c.Admins {
+			if a.UserID == profile.UserID {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			c.Admins = append(c.Admins, profile)
+		}
+	}
+	for _, rule := range community.Rules {
+		c.Rules = append(c.Rules, CommunityRule{
+			ID:          rule.RestID,
+			Name:        rule.Name,
+			Description: rule.Description,
+		})
+	}
+	return c
+}
+
+type communityTimeline struct {
+	Data struct {
+		CommunityResults struct {
+			Result struct {
+				RankedCommunityTimeline struct {
+					Timeline struct {
+						Instructions []instruction `json:"instructions"`
+					} `json:"timeline"`
+				} `json:"ranked_community_timeline"`
+				MembersSlice struct {
+					ItemsResults []struct {
+						Result userResult `json:"result"`
+					} `json:"items_results"`
+					SliceInfo struct {
+						NextCursor string `json:"next_cursor"`
+					} `json:"slice_info"`
+				} `json:"members_slice"`
+			} `json:"result"`
+		} `json:"communityResults"`
+	} `json:"data"`
+}
+
+// GetCommunity return community metadata by ID.
+func (s *Scraper) GetCommunity(communityID string) (*Community, error) {
+	req, err := s.newRequest("GET", communityURL)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"communityId":              communityID,
+		"withDmMuting":             false,
+		"withSafetyModeUserFields": true,
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(timelineFeatures()))
+	req.URL.RawQuery = query.Encode()
+
+	var jsn struct {
+		Data struct {
+			CommunityResults struct {
+				Result communityInfo `json:"result"`
+			} `json:"communityResults"`
+		} `json:"data"`
+	}
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, err
+	}
+
+	community := jsn.Data.CommunityResults.Result.parse()
+	if community == nil {
+		return nil, fmt.Errorf("community with ID %s not found", communityID)
+	}
+	return community, nil
+}
+
+// GetCommunityTweets returns channel with tweets of a given community.
+func (s *Scraper) GetCommunityTweets(ctx context.Context, communityID string, maxTweetsNbr int, ranking CommunityRanking) <-chan *TweetResult {
+	return getTweetTimeline(ctx, communityID, maxTweetsNbr, timelineFilter{}, func(communityID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.FetchCommunityTweets(communityID, maxTweetsNbr, cursor, ranking)
+	})
+}
+
+// GetCommunityMembers returns channel with members of a given community.
+func (s *Scraper) GetCommunityMembers(ctx context.Context, communityID string, maxProfilesNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, communityID, maxProfilesNbr, s.FetchCommunityMembers)
+}
+
+// FetchCommunityTweets gets tweets of a given community, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchCommunityTweets(communityID string, maxTweetsNbr int, cursor string, ranking CommunityRanking) ([]*Tweet, string, error) {
+	if maxTweetsNbr > 100 {
+		maxTweetsNbr = 100
+	}
+
+	variables := map[string]interface{}{
+		"communityId":            communityID,
+		"count":                  maxTweetsNbr,
+		"includePromotedContent": false,
+		"rankingMode":            "Relevance",
+		"withCommunity":          true,
+	}
+	if ranking == CommunityLatest {
+		variables["rankingMode"] = "Recency"
+	}
+
+	timeline, err := s.getCommunityTimeline(communityTweetsURL, variables, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tweets, nextCursor := parseTimelineTweets(timeline.Data.CommunityResults.Result.RankedCommunityTimeline.Timeline.Instructions, false)
+	for _, tweet := range tweets {
+		if tweet.CommunityID == "" {
+			tweet.CommunityID = communityID
+		}
+	}
+	return tweets, nextCursor, nil
+}
+
+// FetchCommunityMembers gets members of a given community, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchCommunityMembers(communityID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	variables := map[string]interface{}{
+		"communityId": communityID,
+	}
+
+	timeline, err := s.getCommunityTimeline(communityMembersURL, variables, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var profiles []*Profile
+	slice := timeline.Data.CommunityResults.Result.MembersSlice
+	for _, item := range slice.ItemsResults {
+		if profile := item.Result.parse(); profile.Username != "" {
+			profiles = append(profiles, &profile)
+		}
+	}
+	return profiles, slice.SliceInfo.NextCursor, nil
+}
+
+func (s *Scraper) getCommunityTimeline(endpoint string, variables map[string]interface{}, cursor string) (*communityTimeline, error) {
+	req, err := s.newRequest("GET", endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(timelineFeatures()))
+	req.URL.RawQuery = query.Encode()
+
+	var timeline communityTimeline
+	err = s.RequestAPI(req, &timeline)
+	if err != nil {
+		return nil, err
+	}
+	return &timeline, nil
+}
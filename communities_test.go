@@ -0,0 +1,73 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+// public community used in tests
+const testCommunityID = "1493446837214187523"
+
+func TestGetCommunity(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	community, err := testScraper.GetCommunity(testCommunityID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if community.ID != testCommunityID {
+		t.Errorf("Expected community ID %s, got %s", testCommunityID, community.ID)
+	}
+	if community.Name == "" {
+		t.Error("Expected community Name is empty")
+	}
+	if community.MemberCount == 0 {
+		t.Error("Expected MemberCount is greater than zero")
+	}
+	if len(community.Admins) == 0 {
+		t.Error("Expected community Admins is empty")
+	}
+}
+
+func TestGetCommunityTweets(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	for _, ranking := range []twitterscraper.CommunityRanking{twitterscraper.CommunityTop, twitterscraper.CommunityLatest} {
+		count := 0
+		for tweet := range testScraper.GetCommunityTweets(context.Background(), testCommunityID, 20, ranking) {
+			if tweet.Error != nil {
+				t.Fatal(tweet.Error)
+			}
+			count++
+			if tweet.CommunityID != testCommunityID {
+				t.Errorf("Expected tweet CommunityID %s, got %s", testCommunityID, tweet.CommunityID)
+			}
+		}
+		if count == 0 {
+			t.Errorf("Expected community tweets for ranking %v", ranking)
+		}
+	}
+}
+
+func TestGetCommunityMembers(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	count := 0
+	for profile := range testScraper.GetCommunityMembers(context.Background(), testCommunityID, 20) {
+		if profile.Error != nil {
+			t.Fatal(profile.Error)
+		}
+		count++
+		if profile.UserID == "" {
+			t.Error("Expected member UserID is empty")
+		}
+	}
+	if count == 0 {
+		t.Error("Expected community members")
+	}
+}
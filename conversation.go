@@ -0,0 +1,58 @@
+package twitterscraper
+
+import "strings"
+
+// ReplyPolicy is who can reply to the tweet
+type ReplyPolicy string
+
+const (
+	// ReplyEveryone - no conversation control
+	ReplyEveryone ReplyPolicy = "everyone"
+	// ReplyFollowing - people followed by the author
+	ReplyFollowing ReplyPolicy = "following"
+	// ReplyMentioned - only people mentioned in the tweet
+	ReplyMentioned ReplyPolicy = "mentioned"
+	// ReplySubscribers - subscribers of the author
+	ReplySubscribers ReplyPolicy = "subscribers"
+	// ReplyVerified - verified accounts
+	ReplyVerified ReplyPolicy = "verified"
+)
+
+func parseReplyPolicy(policy string) ReplyPolicy {
+	switch policy {
+	case "":
+		return ReplyEveryone
+	case "Community":
+		return ReplyFollowing
+	case "ByInvitation":
+		return ReplyMentioned
+	case "Subscribers":
+		return ReplySubscribers
+	case "Verified":
+		return ReplyVerified
+	}
+	return ReplyPolicy(strings.ToLower(policy))
+}
+
+// parseSource returns client name from HTML link like <a href="...">Twitter for iPhone</a>
+func parseSource(source string) string {
+	start := strings.Index(source, ">")
+	end := strings.LastIndex(source, "</a>")
+	if start < 0 || end <= start {
+		return source
+	}
+	return textUnescaper.Replace(source[start+1 : end])
+}
+
+// parseTweetDetails fills language, source, counters and reply settings of the tweet
+func parseTweetDetails(tw *Tweet, tweet *legacyTweet) {
+	tw.BookmarkCount = tweet.BookmarkCount
+	tw.InReplyToUserID = tweet.InReplyToUserIDStr
+	tw.InReplyToUsername = tweet.InReplyToScreenName
+	tw.IsTranslatable = tweet.IsTranslatable
+	tw.Lang = tweet.Lang
+	tw.PossiblySensitive = tweet.PossiblySensitive
+	tw.QuoteCount = tweet.QuoteCount
+	tw.ReplyPolicy = parseReplyPolicy(tweet.ConversationControl.Policy)
+	tw.Source = parseSource(tweet.Source)
+}
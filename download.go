@@ -0,0 +1,374 @@
+package twitterscraper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultFileTemplate names downloaded files as user_tweetID_index.ext
+const DefaultFileTemplate = "{user}_{tweet_id}_{index}.{ext}"
+
+// Downloader saves photos, videos and GIFs of tweets to local files
+type Downloader struct {
+	concurrency  int
+	dir          string
+	maxBandwidth int
+	maxHeight    int
+	retries      int
+	scraper      *Scraper
+	template     string
+
+	mu     sync.Mutex
+	hashes map[string]string
+}
+
+// DownloadResult of media downloading
+type DownloadResult struct {
+	// Duplicate is set when the same content was already saved to Path
+	Duplicate bool
+	MediaID   string
+	Path      string
+	TweetID   string
+	URL       string
+	Error     error
+}
+
+type mediaItem struct {
+	// ext overrides extension of URL
+	ext     string
+	hls     bool
+	index   int
+	mediaID string
+	tweet   *Tweet
+	url     string
+}
+
+// NewDownloader creates a media Downloader with proxy of the scraper
+func (s *Scraper) NewDownloader() *Downloader {
+	return &Downloader{
+		concurrency: 4,
+		dir:         ".",
+		retries:     3,
+		scraper:     s,
+		template:    DefaultFileTemplate,
+		hashes:      make(map[string]string),
+	}
+}
+
+// WithConcurrency set number of parallel downloads
+func (d *Downloader) WithConcurrency(n int) *Downloader {
+	if n > 0 {
+		d.concurrency = n
+	}
+	return d
+}
+
+// WithDir set directory for downloaded files
+func (d *Downloader) WithDir(dir string) *Downloader {
+	d.dir = dir
+	return d
+}
+
+// WithFileTemplate set template of file names.
+// Supported placeholders: {user}, {tweet_id}, {index}, {media_id} and {ext}.
+func (d *Downloader) WithFileTemplate(template string) *Downloader {
+	d.template = template
+	return d
+}
+
+// Download saves media of tweets and returns result for each media item.
+// Partially downloaded files are resumed, files with the same content are saved once.
+func (d *Downloader) Download(ctx context.Context, tweets ...*Tweet) []*DownloadResult {
+	var items []mediaItem
+	for _, tweet := range tweets {
+		index := 0
+		for _, photo := range tweet.Photos {
+			index++
+			items = append(items, mediaItem{index: index, mediaID: photo.ID, tweet: tweet, url: originalPhotoURL(photo.URL)})
+		}
+		for _, video := range tweet.Videos {
+			index++
+			item := mediaItem{index: index, mediaID: video.ID, tweet: tweet, url: bestVideoURL(video)}
+			if item.url == "" && video.HLSURL != "" {
+				item.hls = true
+				item.url = video.HLSURL
+			}
+			items = append(items, item)
+		}
+		for _, gif := range tweet.GIFs {
+			index++
+			items = append(items, mediaItem{index: index, mediaID: gif.ID, tweet: tweet, url: gif.URL})
+		}
+	}
+
+	results := make([]*DownloadResult, len(items))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < d.concurrency && w < len(items); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = d.download(ctx, items[i])
+			}
+		}()
+	}
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+func (d *Downloader) download(ctx context.Context, item mediaItem) *DownloadResult {
+	result := &DownloadResult{
+		MediaID: item.mediaID,
+		TweetID: item.tweet.ID,
+		URL:     item.url,
+	}
+	if ctx.Err() != nil {
+		result.Error = ctx.Err()
+		return result
+	}
+	if item.url == "" {
+		result.Error = fmt.Errorf("media %s has no URL", item.mediaID)
+		return result
+	}
+	var stream *hlsStream
+	if item.hls {
+		// the container of HLS stream is known from its playlists
+		var err error
+		if stream, err = d.openHLS(ctx, item.url); err != nil {
+			result.Error = err
+			return result
+		}
+		item.ext = stream.ext()
+	}
+	result.Path = filepath.Join(d.dir, d.fileName(item))
+	if err := os.MkdirAll(filepath.Dir(result.Path), 0755); err != nil {
+		result.Error = err
+		return result
+	}
+
+	if _, err := os.Stat(result.Path); err != nil {
+		if stream != nil {
+			err = d.fetchHLS(ctx, stream, result.Path+".part")
+		} else {
+			err = d.fetch(ctx, item.url, result.Path+".part")
+		}
+		if err != nil {
+			result.Error = err
+			return result
+		}
+	}
+
+	hash, err := fileHash(result.Path + ".part")
+	partial := err == nil
+	if os.IsNotExist(err) {
+		hash, err = fileHash(result.Path)
+	}
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if existing, ok := d.hashes[hash]; ok && existing != result.Path {
+		if partial {
+			os.Remove(result.Path + ".part")
+		}
+		result.Duplicate = true
+		result.Path = existing
+		return result
+	}
+	d.hashes[hash] = result.Path
+	if partial {
+		result.Error = os.Rename(result.Path+".part", result.Path)
+	}
+	return result
+}
+
+// fetch downloads URL to the file, existing content of the file is resumed by Range request
+func (d *Downloader) fetch(ctx context.Context, mediaURL, partPath string) error {
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+	}
+
+	resp, err := d.rangeRequest(ctx, mediaURL, offset)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusPartialContent && contentRangeStart(resp.Header.Get("Content-Range")) != offset {
+		// the range is ignored by server, the file is downloaded from the beginning
+		resp.Body.Close()
+		offset = 0
+		resp, err = d.rangeRequest(ctx, mediaURL, 0)
+		if err != nil {
+			return err
+		}
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusOK:
+		flags |= os.O_TRUNC
+	case http.StatusPartialContent:
+		if offset == 0 {
+			flags |= os.O_TRUNC
+		} else {
+			flags |= os.O_APPEND
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		if offset > 0 {
+			// the file is already complete
+			return nil
+		}
+		fallthrough
+	default:
+		return fmt.Errorf("response status %s", resp.Status)
+	}
+
+	file, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// rangeRequest requests URL from the offset, whole content for zero offset
+func (d *Downloader) rangeRequest(ctx context.Context, mediaURL string, offset int64) (*http.Response, error) {
+	req, err := http.NewRequest("GET", mediaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+	return d.httpClient().Do(req)
+}
+
+// contentRangeStart returns the first byte of Content-Range like "bytes 300-999/1000", -1 if invalid
+func contentRangeStart(contentRange string) int64 {
+	if !strings.HasPrefix(contentRange, "bytes ") {
+		return -1
+	}
+	contentRange = strings.TrimPrefix(contentRange, "bytes ")
+	dash := strings.IndexByte(contentRange, '-')
+	if dash < 0 {
+		return -1
+	}
+	start, err := strconv.ParseInt(contentRange[:dash], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return start
+}
+
+// fetchHLS downloads HLS stream to the file, it can't be resumed
+func (d *Downloader) fetchHLS(ctx context.Context, stream *hlsStream, partPath string) error {
+	file, err := os.Create(partPath)
+	if err != nil {
+		return err
+	}
+	if err := d.writeHLS(ctx, stream, file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// httpClient returns client with proxy of the scraper and without timeout, large videos take a while.
+// Cookies of the session are not sent to media hosts.
+func (d *Downloader) httpClient() *http.Client {
+	return &http.Client{
+		Transport: d.scraper.client.Transport,
+	}
+}
+
+func (d *Downloader) fileName(item mediaItem) string {
+	ext := item.ext
+	if ext == "" {
+		ext = mediaExt(item.url)
+	}
+	return strings.NewReplacer(
+		"{user}", item.tweet.Username,
+		"{tweet_id}", item.tweet.ID,
+		"{index}", strconv.Itoa(item.index),
+		"{media_id}", item.mediaID,
+		"{ext}", ext,
+	).Replace(d.template)
+}
+
+func fileHash(name string) (string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// originalPhotoURL returns URL of the photo in original size
+func originalPhotoURL(photoURL string) string {
+	u, err := url.Parse(photoURL)
+	if err != nil {
+		return photoURL
+	}
+	query := u.Query()
+	if ext := path.Ext(u.Path); ext != "" {
+		u.Path = strings.TrimSuffix(u.Path, ext)
+		query.Set("format", strings.TrimPrefix(ext, "."))
+	}
+	query.Set("name", "orig")
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// bestVideoURL returns MP4 variant of the video with the highest bitrate
+func bestVideoURL(video Video) string {
+	best := video.URL
+	maxBitrate := -1
+	for _, variant := range video.Variants {
+		if variant.ContentType == "video/mp4" && variant.Bitrate > maxBitrate {
+			best = variant.URL
+			maxBitrate = variant.Bitrate
+		}
+	}
+	return best
+}
+
+// mediaExt returns file extension of media URL without dot
+func mediaExt(mediaURL string) string {
+	u, err := url.Parse(mediaURL)
+	if err != nil {
+		return ""
+	}
+	if format := u.Query().Get("format"); format != "" {
+		return format
+	}
+	return strings.TrimPrefix(path.Ext(u.Path), ".")
+}
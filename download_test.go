@@ -0,0 +1,205 @@
+package twitterscraper_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+type mediaServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	files    map[string][]byte
+	requests []*http.Request
+}
+
+func newMediaServer(files map[string][]byte) *mediaServer {
+	s := &mediaServer{files: files}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r)
+		s.mu.Unlock()
+		content, ok := s.files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, r.URL.Path, time.Time{}, bytes.NewReader(content))
+	}))
+	return s
+}
+
+func (s *mediaServer) request(path string) *http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.requests {
+		if r.URL.Path == path {
+			return r
+		}
+	}
+	return nil
+}
+
+func assertFile(t *testing.T, name string, expected []byte) {
+	t.Helper()
+	content, err := os.ReadFile(name)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(content, expected) {
+		t.Errorf("Unexpected content of %s: %q", name, content)
+	}
+}
+
+func TestDownloader(t *testing.T) {
+	server := newMediaServer(map[string][]byte{
+		"/media/photo":    []byte("photo"),
+		"/video/low.mp4":  []byte("low"),
+		"/video/high.mp4": []byte("high"),
+		"/gif/anim.mp4":   []byte("gif"),
+	})
+	defer server.Close()
+
+	tweet := &twitterscraper.Tweet{
+		ID:       "100",
+		Username: "user",
+		Photos:   []twitterscraper.Photo{{ID: "1", URL: server.URL + "/media/photo.jpg"}},
+		Videos: []twitterscraper.Video{{
+			ID:  "2",
+			URL: server.URL + "/video/low.mp4",
+			Variants: []twitterscraper.Variant{
+				{Bitrate: 256000, ContentType: "video/mp4", URL: server.URL + "/video/low.mp4"},
+				{ContentType: "application/x-mpegURL", URL: server.URL + "/video/pl.m3u8"},
+				{Bitrate: 2176000, ContentType: "video/mp4", URL: server.URL + "/video/high.mp4"},
+			},
+		}},
+		GIFs: []twitterscraper.GIF{{ID: "3", URL: server.URL + "/gif/anim.mp4"}},
+	}
+
+	dir := t.TempDir()
+	results := twitterscraper.New().NewDownloader().WithDir(dir).Download(context.Background(), tweet)
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			t.Errorf("Download %s error: %v", result.URL, result.Error)
+		}
+	}
+	assertFile(t, filepath.Join(dir, "user_100_1.jpg"), []byte("photo"))
+	assertFile(t, filepath.Join(dir, "user_100_2.mp4"), []byte("high"))
+	assertFile(t, filepath.Join(dir, "user_100_3.mp4"), []byte("gif"))
+
+	photoRequest := server.request("/media/photo")
+	if photoRequest == nil {
+		t.Fatal("Photo was not requested")
+	}
+	if name := photoRequest.URL.Query().Get("name"); name != "orig" {
+		t.Errorf("Expected photo name=orig, got %q", name)
+	}
+}
+
+func TestDownloaderResume(t *testing.T) {
+	content := []byte(strings.Repeat("0123456789", 100))
+	server := newMediaServer(map[string][]byte{"/video/file.mp4": content})
+	defer server.Close()
+
+	dir := t.TempDir()
+	name := filepath.Join(dir, "100-2.mp4")
+	if err := os.WriteFile(name+".part", content[:300], 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tweet := &twitterscraper.Tweet{
+		ID:     "100",
+		Videos: []twitterscraper.Video{{ID: "2", URL: server.URL + "/video/file.mp4"}},
+	}
+	results := twitterscraper.New().NewDownloader().
+		WithDir(dir).
+		WithFileTemplate("{tweet_id}-{media_id}.{ext}").
+		Download(context.Background(), tweet)
+	if results[0].Error != nil {
+		t.Fatal(results[0].Error)
+	}
+	if results[0].Path != name {
+		t.Errorf("Expected path %s, got %s", name, results[0].Path)
+	}
+	assertFile(t, name, content)
+	if _, err := os.Stat(name + ".part"); !os.IsNotExist(err) {
+		t.Error("Expected partial file to be removed")
+	}
+	if r := server.request("/video/file.mp4"); r == nil || r.Header.Get("Range") != "bytes=300-" {
+		t.Error("Expected download to be resumed with Range request")
+	}
+}
+
+func TestDownloaderResumeIgnoredRange(t *testing.T) {
+	content := []byte(strings.Repeat("0123456789", 100))
+	// server answers partial content from the beginning regardless of Range
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Range", "bytes 0-999/1000")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	name := filepath.Join(dir, "100-2.mp4")
+	if err := os.WriteFile(name+".part", content[:300], 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tweet := &twitterscraper.Tweet{
+		ID:     "100",
+		Videos: []twitterscraper.Video{{ID: "2", URL: server.URL + "/video/file.mp4"}},
+	}
+	results := twitterscraper.New().NewDownloader().
+		WithDir(dir).
+		WithFileTemplate("{tweet_id}-{media_id}.{ext}").
+		Download(context.Background(), tweet)
+	if results[0].Error != nil {
+		t.Fatal(results[0].Error)
+	}
+	assertFile(t, name, content)
+}
+
+func TestDownloaderDuplicates(t *testing.T) {
+	server := newMediaServer(map[string][]byte{
+		"/media/a": []byte("same"),
+		"/media/b": []byte("same"),
+	})
+	defer server.Close()
+
+	tweets := []*twitterscraper.Tweet{
+		{ID: "1", Username: "user", Photos: []twitterscraper.Photo{{ID: "1", URL: server.URL + "/media/a.png"}}},
+		{ID: "2", Username: "user", Photos: []twitterscraper.Photo{{ID: "2", URL: server.URL + "/media/b.png"}}},
+	}
+	dir := t.TempDir()
+	results := twitterscraper.New().NewDownloader().WithDir(dir).WithConcurrency(1).Download(context.Background(), tweets...)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if results[0].Error != nil || results[1].Error != nil {
+		t.Fatal(results[0].Error, results[1].Error)
+	}
+	if results[0].Duplicate || !results[1].Duplicate {
+		t.Error("Expected the second photo to be a duplicate")
+	}
+	if results[1].Path != results[0].Path {
+		t.Errorf("Expected duplicate path %s, got %s", results[0].Path, results[1].Path)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(files) != 1 {
+		t.Errorf("Expected 1 file, got %v", files)
+	}
+}
@@ -0,0 +1,121 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+type (
+	editControlInitial struct {
+		EditTweetIDs       []string      `json:"edit_tweet_ids"`
+		EditableUntilMsecs msecTimestamp `json:"editable_until_msecs"`
+		EditsRemaining     string        `json:"edits_remaining"`
+		IsEditEligible     bool          `json:"is_edit_eligible"`
+	}
+
+	// GraphQL API edit control, for edited versions of tweet
+	// the data of initial tweet is in EditControlInitial
+	editControl struct {
+		editControlInitial
+		InitialTweetID     string              `json:"initial_tweet_id"`
+		EditControlInitial *editControlInitial `json:"edit_control_initial"`
+	}
+
+	// legacy API edit control from "ext" section
+	legacyEditControl struct {
+		Initial *struct {
+			EditTweetIDs       []string      `json:"editTweetIds"`
+			EditableUntilMsecs msecTimestamp `json:"editableUntilMsecs"`
+			EditsRemaining     string        `json:"editsRemaining"`
+			IsEditEligible     bool          `json:"isEditEligible"`
+		} `json:"initial"`
+		Edit *struct {
+			InitialTweetID     string `json:"initialTweetId"`
+			EditControlInitial struct {
+				EditTweetIDs       []string      `json:"editTweetIds"`
+				EditableUntilMsecs msecTimestamp `json:"editableUntilMsecs"`
+				EditsRemaining     string        `json:"editsRemaining"`
+				IsEditEligible     bool          `json:"isEditEligible"`
+			} `json:"editControlInitial"`
+		} `json:"edit"`
+	}
+)
+
+func (control *editControl) parse(tw *Tweet) {
+	initial := &control.editControlInitial
+	if control.EditControlInitial != nil {
+		initial = control.EditControlInitial
+	}
+	tw.EditHistoryIDs = initial.EditTweetIDs
+	tw.EditableUntil = initial.EditableUntilMsecs.Time()
+	tw.EditsRemaining, _ = strconv.Atoi(initial.EditsRemaining)
+	tw.IsEditEligible = initial.IsEditEligible
+}
+
+func (control *legacyEditControl) parse(tw *Tweet) {
+	var initial editControlInitial
+	if control.Initial != nil {
+		initial.EditTweetIDs = control.Initial.EditTweetIDs
+		initial.EditableUntilMsecs = control.Initial.EditableUntilMsecs
+		initial.EditsRemaining = control.Initial.EditsRemaining
+		initial.IsEditEligible = control.Initial.IsEditEligible
+	} else if control.Edit != nil {
+		initial.EditTweetIDs = control.Edit.EditControlInitial.EditTweetIDs
+		initial.EditableUntilMsecs = control.Edit.EditControlInitial.EditableUntilMsecs
+		initial.EditsRemaining = control.Edit.EditControlInitial.EditsRemaining
+		initial.IsEditEligible = control.Edit.EditControlInitial.IsEditEligible
+	} else {
+		return
+	}
+	(&editControl{editControlInitial: initial}).parse(tw)
+}
+
+// GetTweetEditHistory returns all versions of tweet from the first to the latest one.
+func (s *Scraper) GetTweetEditHistory(ctx context.Context, id string) ([]*Tweet, error) {
+	tweet, err := s.getTweet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(tweet.EditHistoryIDs) <= 1 {
+		return []*Tweet{tweet}, nil
+	}
+
+	var versions []*Tweet
+	for _, versionID := range tweet.EditHistoryIDs {
+		if versionID == tweet.ID {
+			versions = append(versions, tweet)
+			continue
+		}
+		version, err := s.getTweetVersion(ctx, versionID)
+		if err != nil {
+			return nil, fmt.Errorf("get tweet version %s: %v", versionID, err)
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// getTweetVersion picks the version by ID from the conversation,
+// as the focal tweet of an old version can be the latest one
+func (s *Scraper) getTweetVersion(ctx context.Context, versionID string) (*Tweet, error) {
+	if s.isOpenAccount {
+		return s.getTweet(ctx, versionID)
+	}
+	conversation, err := s.fetchTweetDetail(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+	return conversation.version(versionID)
+}
+
+// version returns the tweet of conversation with the version ID,
+// entries carrying the data of another version are not accepted
+func (conversation *threadedConversation) version(versionID string) (*Tweet, error) {
+	for _, tweet := range conversation.parse() {
+		if tweet.ID == versionID {
+			return tweet, nil
+		}
+	}
+	return nil, fmt.Errorf("tweet with ID %s not found", versionID)
+}
@@ -0,0 +1,19 @@
+package twitterscraper
+
+import "testing"
+
+func TestConversationVersion(t *testing.T) {
+	// detail of the old version returns the latest one in its entry
+	var conversation threadedConversation
+	loadFixture(t, "edit/stale_version.json", &conversation)
+	if tweet, err := conversation.version("1700000000000000001"); err == nil {
+		t.Errorf("Expected error for version carrying another one, got tweet %s %q", tweet.ID, tweet.Text)
+	}
+	tweet, err := conversation.version("1700000000000000002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.Text != "Edited text" || len(tweet.EditHistoryIDs) != 2 {
+		t.Errorf("Unexpected latest version %q with history %v", tweet.Text, tweet.EditHistoryIDs)
+	}
+}
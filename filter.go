@@ -0,0 +1,141 @@
+package twitterscraper
+
+import "time"
+
+// TweetFilter reports whether the tweet is kept in timeline
+type TweetFilter func(tweet *Tweet) bool
+
+// And keeps tweets matching all filters
+func And(filters ...TweetFilter) TweetFilter {
+	return func(tweet *Tweet) bool {
+		for _, filter := range filters {
+			if !filter(tweet) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or keeps tweets matching any of filters
+func Or(filters ...TweetFilter) TweetFilter {
+	return func(tweet *Tweet) bool {
+		for _, filter := range filters {
+			if filter(tweet) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not keeps tweets not matching the filter
+func Not(filter TweetFilter) TweetFilter {
+	return func(tweet *Tweet) bool {
+		return !filter(tweet)
+	}
+}
+
+// NoRetweets skips retweets
+func NoRetweets() TweetFilter {
+	return func(tweet *Tweet) bool {
+		return !tweet.IsRetweet
+	}
+}
+
+// NoReplies skips replies
+func NoReplies() TweetFilter {
+	return func(tweet *Tweet) bool {
+		return !tweet.IsReply
+	}
+}
+
+// HasMedia keeps tweets with photos, videos or GIFs
+func HasMedia() TweetFilter {
+	return func(tweet *Tweet) bool {
+		return len(tweet.Photos) > 0 || len(tweet.Videos) > 0 || len(tweet.GIFs) > 0
+	}
+}
+
+// MinLikes keeps tweets with at least n likes
+func MinLikes(n int) TweetFilter {
+	return func(tweet *Tweet) bool {
+		return tweet.Likes >= n
+	}
+}
+
+// MinRetweets keeps tweets retweeted at least n times
+func MinRetweets(n int) TweetFilter {
+	return func(tweet *Tweet) bool {
+		return tweet.Retweets >= n
+	}
+}
+
+// Lang keeps tweets in the language, e.g. "en"
+func Lang(lang string) TweetFilter {
+	return func(tweet *Tweet) bool {
+		return tweet.Lang == lang
+	}
+}
+
+// Between keeps tweets created since (inclusive) until (exclusive), zero time is unbounded.
+// Tweets of unknown time are skipped.
+func Between(since, until time.Time) TweetFilter {
+	return func(tweet *Tweet) bool {
+		created, ok := tweetTime(tweet)
+		return ok && (since.IsZero() || !created.Before(since)) &&
+			(until.IsZero() || created.Before(until))
+	}
+}
+
+// TimelineOptions of tweets timeline
+type TimelineOptions struct {
+	// Filter keeps tweets it returns true for, nil keeps all tweets
+	Filter TweetFilter
+	// Since (inclusive) and Until (exclusive) bound creation time of tweets, zero time is unbounded.
+	// Chronological timelines (user, list and latest search) stop on the first tweet older than Since.
+	Since time.Time
+	Until time.Time
+}
+
+// timelineFilter applies filter and date bounds of options in timeline loop
+type timelineFilter struct {
+	filter       TweetFilter
+	since, until time.Time
+	// chronological timeline is over on the first tweet older than since
+	chronological bool
+}
+
+func (opts TimelineOptions) timelineFilter(chronological bool) timelineFilter {
+	return timelineFilter{
+		filter:        opts.Filter,
+		since:         opts.Since,
+		until:         opts.Until,
+		chronological: chronological,
+	}
+}
+
+// match reports whether the tweet is kept and whether the rest of timeline is out of bounds.
+// Pinned tweet is out of order, so it doesn't stop the timeline.
+// Date bounds are skipped for tweets of unknown time.
+func (f timelineFilter) match(tweet *Tweet) (keep bool, stop bool) {
+	if created, ok := tweetTime(tweet); ok {
+		if !f.since.IsZero() && created.Before(f.since) {
+			return false, f.chronological && !tweet.IsPin
+		}
+		if !f.until.IsZero() && !created.Before(f.until) {
+			return false, false
+		}
+	}
+	return f.filter == nil || f.filter(tweet), false
+}
+
+// tweetTime returns creation time of the tweet,
+// placeholders of unavailable tweets may have only the ID to take it from
+func tweetTime(tweet *Tweet) (time.Time, bool) {
+	if !tweet.TimeParsed.IsZero() {
+		return tweet.TimeParsed, true
+	}
+	created, err := TweetIDTime(tweet.ID)
+	return created, err == nil
+}
@@ -0,0 +1,54 @@
+package twitterscraper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimelineFilterUnavailable(t *testing.T) {
+	created := time.Date(2023, 9, 8, 4, 15, 59, 129000000, time.UTC)
+	filter := TimelineOptions{Since: created.Add(-time.Hour), Until: created.Add(time.Hour)}.timelineFilter(true)
+
+	// time of placeholder is taken from the snowflake ID
+	tombstone := &Tweet{ID: "1700000000000000005", Unavailable: UnavailableDeleted}
+	if keep, stop := filter.match(tombstone); !keep || stop {
+		t.Errorf("Expected tombstone in bounds kept, got keep=%v stop=%v", keep, stop)
+	}
+	older := &Tweet{ID: "1600000000000000005", Unavailable: UnavailableDeleted}
+	if keep, stop := filter.match(older); keep || !stop {
+		t.Errorf("Expected older tombstone to stop timeline, got keep=%v stop=%v", keep, stop)
+	}
+	// tweet of unknown time doesn't stop timeline
+	unknown := &Tweet{ID: "20", Unavailable: UnavailableDeleted}
+	if keep, stop := filter.match(unknown); !keep || stop {
+		t.Errorf("Expected tweet of unknown time kept, got keep=%v stop=%v", keep, stop)
+	}
+}
+
+func TestTimelineFilterPinned(t *testing.T) {
+	since := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	filter := TimelineOptions{Since: since}.timelineFilter(true)
+	timeline := []*Tweet{
+		{ID: "1", IsPin: true, TimeParsed: since.AddDate(-1, 0, 0)},
+		{ID: "2", TimeParsed: since.Add(2 * time.Hour)},
+		{ID: "3", TimeParsed: since.Add(time.Hour)},
+		{ID: "4", TimeParsed: since.Add(-time.Hour)},
+	}
+	var kept []string
+	for _, tweet := range timeline {
+		keep, stop := filter.match(tweet)
+		if stop {
+			break
+		}
+		if keep {
+			kept = append(kept, tweet.ID)
+		}
+	}
+	// old pinned tweet is skipped without stopping the timeline
+	if len(kept) != 2 || kept[0] != "2" || kept[1] != "3" {
+		t.Errorf("Expected tweets [2 3] kept, got %v", kept)
+	}
+	if _, stop := filter.match(timeline[3]); !stop {
+		t.Error("Expected the first old tweet after pinned one to stop timeline")
+	}
+}
@@ -0,0 +1,66 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestTweetFilter(t *testing.T) {
+	day := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	retweet := &twitterscraper.Tweet{IsRetweet: true, Likes: 100, Lang: "en", TimeParsed: day}
+	photo := &twitterscraper.Tweet{Photos: []twitterscraper.Photo{{ID: "1"}}, Likes: 5, Lang: "fr", TimeParsed: day.Add(-time.Hour)}
+	reply := &twitterscraper.Tweet{IsReply: true, Retweets: 10, Lang: "en", TimeParsed: day.Add(time.Hour)}
+
+	tests := []struct {
+		name   string
+		filter twitterscraper.TweetFilter
+		want   [3]bool
+	}{
+		{"NoRetweets", twitterscraper.NoRetweets(), [3]bool{false, true, true}},
+		{"NoReplies", twitterscraper.NoReplies(), [3]bool{true, true, false}},
+		{"HasMedia", twitterscraper.HasMedia(), [3]bool{false, true, false}},
+		{"MinLikes", twitterscraper.MinLikes(5), [3]bool{true, true, false}},
+		{"MinRetweets", twitterscraper.MinRetweets(1), [3]bool{false, false, true}},
+		{"Lang", twitterscraper.Lang("en"), [3]bool{true, false, true}},
+		{"Between", twitterscraper.Between(day, day.Add(time.Hour)), [3]bool{true, false, false}},
+		{"Between unbounded", twitterscraper.Between(time.Time{}, day), [3]bool{false, true, false}},
+		{"And", twitterscraper.And(twitterscraper.Lang("en"), twitterscraper.NoRetweets()), [3]bool{false, false, true}},
+		{"Or", twitterscraper.Or(twitterscraper.HasMedia(), twitterscraper.MinLikes(50)), [3]bool{true, true, false}},
+		{"Not", twitterscraper.Not(twitterscraper.Lang("en")), [3]bool{false, true, false}},
+	}
+	for _, test := range tests {
+		for i, tweet := range []*twitterscraper.Tweet{retweet, photo, reply} {
+			if got := test.filter(tweet); got != test.want[i] {
+				t.Errorf("%s: tweet %d expected %v, got %v", test.name, i, test.want[i], got)
+			}
+		}
+	}
+	// time of tombstone is taken from its ID
+	tombstone := &twitterscraper.Tweet{ID: "1700000000000000005", Unavailable: twitterscraper.UnavailableDeleted}
+	if !twitterscraper.Between(time.Date(2023, 9, 8, 0, 0, 0, 0, time.UTC), time.Date(2023, 9, 9, 0, 0, 0, 0, time.UTC))(tombstone) {
+		t.Error("Expected tombstone between dates of its ID")
+	}
+}
+
+func TestGetTweetsDateBounds(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	until := time.Now().AddDate(0, 0, -1)
+	since := until.AddDate(0, -6, 0)
+	opts := twitterscraper.TimelineOptions{Filter: twitterscraper.NoRetweets(), Since: since, Until: until}
+	for tweet := range testScraper.GetTweetsWithOptions(context.Background(), "Twitter", 50, opts) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if tweet.IsRetweet {
+			t.Errorf("Expected no retweets, got %s", tweet.ID)
+		}
+		if tweet.TimeParsed.Before(since) || !tweet.TimeParsed.Before(until) {
+			t.Errorf("Expected tweet %s between %s and %s, got %s", tweet.ID, since, until, tweet.TimeParsed)
+		}
+	}
+}
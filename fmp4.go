@@ -0,0 +1,281 @@
+package twitterscraper
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// fmp4Muxer merges fragmented MP4 video and audio renditions into a single stream
+type fmp4Muxer struct {
+	w          io.Writer
+	audioTrack uint32
+	videoTrack uint32
+	sequence   uint32
+}
+
+type mp4Box struct {
+	typ    string
+	header int
+	// data of the whole box with header
+	data []byte
+}
+
+func (box *mp4Box) payload() []byte {
+	return box.data[box.header:]
+}
+
+// newFMP4Muxer writes initialization section with tracks of both renditions,
+// the audio track is numbered after the video one
+func newFMP4Muxer(w io.Writer, videoInit, audioInit []byte) (*fmp4Muxer, error) {
+	videoBoxes, err := readBoxes(videoInit)
+	if err != nil {
+		return nil, err
+	}
+	audioBoxes, err := readBoxes(audioInit)
+	if err != nil {
+		return nil, err
+	}
+	videoMoov, audioMoov := findBox(videoBoxes, "moov"), findBox(audioBoxes, "moov")
+	if videoMoov == nil || audioMoov == nil {
+		return nil, errors.New("initialization section has no moov box")
+	}
+	videoChildren, err := readBoxes(videoMoov.payload())
+	if err != nil {
+		return nil, err
+	}
+	audioChildren, err := readBoxes(cloneBytes(audioMoov.payload()))
+	if err != nil {
+		return nil, err
+	}
+
+	videoTrak, audioTrak := findBox(videoChildren, "trak"), findBox(audioChildren, "trak")
+	if videoTrak == nil || audioTrak == nil {
+		return nil, errors.New("initialization section has no trak box")
+	}
+	m := &fmp4Muxer{w: w}
+	if m.videoTrack, err = fullBoxField(videoTrak, "tkhd", 12, 20); err != nil {
+		return nil, err
+	}
+	m.audioTrack = m.videoTrack + 1
+	if err := setFullBoxField(audioTrak, "tkhd", 12, 20, m.audioTrack); err != nil {
+		return nil, err
+	}
+	var audioTrex []byte
+	if mvex := findBox(audioChildren, "mvex"); mvex != nil {
+		if err := setFullBoxField(mvex, "trex", 4, 4, m.audioTrack); err != nil {
+			return nil, err
+		}
+		trexes, err := readBoxes(mvex.payload())
+		if err != nil {
+			return nil, err
+		}
+		if trex := findBox(trexes, "trex"); trex != nil {
+			audioTrex = trex.data
+		}
+	}
+
+	var moov [][]byte
+	for i := range videoChildren {
+		child := &videoChildren[i]
+		switch child.typ {
+		case "mvhd":
+			mvhd := cloneBytes(child.data)
+			offset := 96
+			if len(mvhd) > 8 && mvhd[8] == 1 {
+				offset = 108
+			}
+			if err := putUint32(mvhd, 8+offset, m.audioTrack+1); err != nil {
+				return nil, err
+			}
+			moov = append(moov, mvhd)
+		case "mvex":
+			mvex := [][]byte{child.payload()}
+			if audioTrex != nil {
+				mvex = append(mvex, audioTrex)
+			}
+			moov = append(moov, makeBox("mvex", mvex...))
+		case "trak":
+			moov = append(moov, child.data, audioTrak.data)
+		default:
+			moov = append(moov, child.data)
+		}
+	}
+
+	if ftyp := findBox(videoBoxes, "ftyp"); ftyp != nil {
+		if _, err := w.Write(ftyp.data); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := w.Write(makeBox("moov", moov...)); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *fmp4Muxer) writeVideo(segment []byte) error {
+	return m.writeFragment(segment, m.videoTrack)
+}
+
+func (m *fmp4Muxer) writeAudio(segment []byte) error {
+	return m.writeFragment(segment, m.audioTrack)
+}
+
+// writeFragment writes movie fragments of the segment renumbered to the track,
+// other boxes (styp, sidx) are dropped as they refer to the source rendition
+func (m *fmp4Muxer) writeFragment(segment []byte, track uint32) error {
+	boxes, err := readBoxes(segment)
+	if err != nil {
+		return err
+	}
+	for i := range boxes {
+		box := &boxes[i]
+		switch box.typ {
+		case "moof":
+			moof := &mp4Box{typ: box.typ, header: box.header, data: cloneBytes(box.data)}
+			children, err := readBoxes(moof.payload())
+			if err != nil {
+				return err
+			}
+			for j := range children {
+				child := &children[j]
+				switch child.typ {
+				case "mfhd":
+					m.sequence++
+					if err := putUint32(child.data, child.header+4, m.sequence); err != nil {
+						return err
+					}
+				case "traf":
+					if err := m.renumberTraf(child, track); err != nil {
+						return err
+					}
+				}
+			}
+			if _, err := m.w.Write(moof.data); err != nil {
+				return err
+			}
+		case "mdat":
+			if _, err := m.w.Write(box.data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *fmp4Muxer) renumberTraf(traf *mp4Box, track uint32) error {
+	children, err := readBoxes(traf.payload())
+	if err != nil {
+		return err
+	}
+	tfhd := findBox(children, "tfhd")
+	if tfhd == nil || len(tfhd.payload()) < 8 {
+		return errors.New("track fragment has no tfhd box")
+	}
+	// data offsets must be relative to the moof box to keep fragments movable
+	if tfhd.payload()[3]&0x01 != 0 {
+		return errors.New("fragment with absolute base data offset is not supported")
+	}
+	return putUint32(tfhd.data, tfhd.header+4, track)
+}
+
+// readBoxes splits data to MP4 boxes, the boxes share memory with data
+func readBoxes(data []byte) ([]mp4Box, error) {
+	var boxes []mp4Box
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return nil, errors.New("truncated MP4 box")
+		}
+		size := uint64(binary.BigEndian.Uint32(data))
+		header := 8
+		switch size {
+		case 0:
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return nil, errors.New("truncated MP4 box")
+			}
+			size = binary.BigEndian.Uint64(data[8:])
+			header = 16
+		}
+		if size < uint64(header) || size > uint64(len(data)) {
+			return nil, fmt.Errorf("invalid size of MP4 box %q", data[4:8])
+		}
+		boxes = append(boxes, mp4Box{typ: string(data[4:8]), header: header, data: data[:size]})
+		data = data[size:]
+	}
+	return boxes, nil
+}
+
+func findBox(boxes []mp4Box, typ string) *mp4Box {
+	for i := range boxes {
+		if boxes[i].typ == typ {
+			return &boxes[i]
+		}
+	}
+	return nil
+}
+
+func makeBox(typ string, children ...[]byte) []byte {
+	size := 8
+	for _, child := range children {
+		size += len(child)
+	}
+	box := make([]byte, 8, size)
+	binary.BigEndian.PutUint32(box, uint32(size))
+	copy(box[4:], typ)
+	for _, child := range children {
+		box = append(box, child...)
+	}
+	return box
+}
+
+// fullBoxField reads uint32 field of full box child of the parent,
+// the offset in payload depends on version of the box
+func fullBoxField(parent *mp4Box, typ string, offsetV0, offsetV1 int) (uint32, error) {
+	box, offset, err := fullBoxOffset(parent, typ, offsetV0, offsetV1)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(box.data[offset:]), nil
+}
+
+func setFullBoxField(parent *mp4Box, typ string, offsetV0, offsetV1 int, value uint32) error {
+	box, offset, err := fullBoxOffset(parent, typ, offsetV0, offsetV1)
+	if err != nil {
+		return err
+	}
+	return putUint32(box.data, offset, value)
+}
+
+func fullBoxOffset(parent *mp4Box, typ string, offsetV0, offsetV1 int) (*mp4Box, int, error) {
+	children, err := readBoxes(parent.payload())
+	if err != nil {
+		return nil, 0, err
+	}
+	box := findBox(children, typ)
+	if box == nil || len(box.payload()) == 0 {
+		return nil, 0, fmt.Errorf("no %s box in %s", typ, parent.typ)
+	}
+	offset := box.header + offsetV0
+	if box.payload()[0] == 1 {
+		offset = box.header + offsetV1
+	}
+	if offset+4 > len(box.data) {
+		return nil, 0, fmt.Errorf("truncated %s box", typ)
+	}
+	return box, offset, nil
+}
+
+func putUint32(data []byte, offset int, value uint32) error {
+	if offset+4 > len(data) {
+		return errors.New("truncated MP4 box")
+	}
+	binary.BigEndian.PutUint32(data[offset:], value)
+	return nil
+}
+
+func cloneBytes(data []byte) []byte {
+	return append([]byte(nil), data...)
+}
@@ -0,0 +1,173 @@
+package twitterscraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fullBox makes MP4 full box of version 0 with uint32 fields
+func fullBox(typ string, flags uint32, fields ...uint32) []byte {
+	payload := make([]byte, 4+4*len(fields))
+	binary.BigEndian.PutUint32(payload, flags)
+	for i, field := range fields {
+		binary.BigEndian.PutUint32(payload[4+4*i:], field)
+	}
+	return makeBox(typ, payload)
+}
+
+func testInitSegment(track uint32) []byte {
+	mvhd := make([]uint32, 24) // next_track_ID is the last field
+	mvhd[23] = track + 1
+	tkhd := make([]uint32, 20) // track_ID after creation and modification time
+	tkhd[2] = track
+	return append(makeBox("ftyp", []byte("iso6")),
+		makeBox("moov",
+			fullBox("mvhd", 0, mvhd...),
+			makeBox("trak", fullBox("tkhd", 3, tkhd...)),
+			makeBox("mvex", fullBox("trex", 0, track, 1, 0, 0, 0)),
+		)...)
+}
+
+func testMediaSegment(track, sequence uint32, content string) []byte {
+	segment := makeBox("styp", []byte("msdh"))
+	segment = append(segment, makeBox("moof",
+		fullBox("mfhd", 0, sequence),
+		makeBox("traf", fullBox("tfhd", 0x020000, track)),
+	)...)
+	return append(segment, makeBox("mdat", []byte(content))...)
+}
+
+func childBoxes(t *testing.T, box *mp4Box) []mp4Box {
+	t.Helper()
+	children, err := readBoxes(box.payload())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return children
+}
+
+// checkMuxedStream verifies init section with two tracks and fragments of alternating tracks
+func checkMuxedStream(t *testing.T, data []byte, mdats []string) {
+	t.Helper()
+	boxes, err := readBoxes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(boxes) != 2+2*len(mdats) || boxes[0].typ != "ftyp" || boxes[1].typ != "moov" {
+		t.Fatalf("Unexpected boxes of muxed stream %d", len(boxes))
+	}
+	var traks, trexes []uint32
+	for _, child := range childBoxes(t, &boxes[1]) {
+		switch child.typ {
+		case "mvhd":
+			if next := binary.BigEndian.Uint32(child.payload()[96:]); next != 3 {
+				t.Errorf("Expected next track ID 3, got %d", next)
+			}
+		case "trak":
+			tkhd := findBox(childBoxes(t, &child), "tkhd")
+			traks = append(traks, binary.BigEndian.Uint32(tkhd.payload()[12:]))
+		case "mvex":
+			for _, trex := range childBoxes(t, &child) {
+				trexes = append(trexes, binary.BigEndian.Uint32(trex.payload()[4:]))
+			}
+		}
+	}
+	if len(traks) != 2 || traks[0] != 1 || traks[1] != 2 {
+		t.Errorf("Expected tracks [1 2], got %v", traks)
+	}
+	if len(trexes) != 2 || trexes[0] != 1 || trexes[1] != 2 {
+		t.Errorf("Expected trex tracks [1 2], got %v", trexes)
+	}
+
+	for i, content := range mdats {
+		moof, mdat := &boxes[2+2*i], &boxes[3+2*i]
+		if moof.typ != "moof" || mdat.typ != "mdat" {
+			t.Fatalf("Expected moof and mdat, got %s and %s", moof.typ, mdat.typ)
+		}
+		children := childBoxes(t, moof)
+		if sequence := binary.BigEndian.Uint32(findBox(children, "mfhd").payload()[4:]); sequence != uint32(i+1) {
+			t.Errorf("Fragment %d: expected sequence %d, got %d", i, i+1, sequence)
+		}
+		tfhd := findBox(childBoxes(t, findBox(children, "traf")), "tfhd")
+		if track := binary.BigEndian.Uint32(tfhd.payload()[4:]); track != uint32(i%2+1) {
+			t.Errorf("Fragment %d: expected track %d, got %d", i, i%2+1, track)
+		}
+		if string(mdat.payload()) != content {
+			t.Errorf("Fragment %d: expected %q, got %q", i, content, mdat.payload())
+		}
+	}
+}
+
+func TestFMP4Muxer(t *testing.T) {
+	var buf bytes.Buffer
+	// both renditions have track ID 1
+	muxer, err := newFMP4Muxer(&buf, testInitSegment(1), testInitSegment(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, content := range []string{"v0", "a0", "v1", "a1"} {
+		write := muxer.writeVideo
+		if i%2 == 1 {
+			write = muxer.writeAudio
+		}
+		if err := write(testMediaSegment(1, uint32(i/2+1), content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkMuxedStream(t, buf.Bytes(), []string{"v0", "a0", "v1", "a1"})
+}
+
+func TestDownloadHLSAudioRendition(t *testing.T) {
+	files := map[string][]byte{
+		"/master.m3u8": []byte(`#EXTM3U
+#EXT-X-MEDIA:NAME="Audio",TYPE=AUDIO,GROUP-ID="audio-64000",AUTOSELECT=YES,URI="/audio/playlist.m3u8"
+#EXT-X-STREAM-INF:BANDWIDTH=2592000,RESOLUTION=1280x720,CODECS="avc1.640020",AUDIO="audio-64000"
+/video/playlist.m3u8
+`),
+		"/video/playlist.m3u8": []byte("#EXTM3U\n#EXT-X-TARGETDURATION:3\n#EXT-X-MAP:URI=\"init.mp4\"\n#EXTINF:3.0,\n0.m4s\n#EXTINF:3.0,\n1.m4s\n#EXT-X-ENDLIST\n"),
+		"/audio/playlist.m3u8": []byte("#EXTM3U\n#EXT-X-TARGETDURATION:3\n#EXT-X-MAP:URI=\"init.mp4\"\n#EXTINF:3.0,\n0.m4s\n#EXTINF:3.0,\n1.m4s\n#EXT-X-ENDLIST\n"),
+		"/video/init.mp4":      testInitSegment(1),
+		"/video/0.m4s":         testMediaSegment(1, 1, "v0"),
+		"/video/1.m4s":         testMediaSegment(1, 2, "v1"),
+		"/audio/init.mp4":      testInitSegment(1),
+		"/audio/0.m4s":         testMediaSegment(1, 1, "a0"),
+		"/audio/1.m4s":         testMediaSegment(1, 2, "a1"),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	if err := New().NewDownloader().DownloadHLS(context.Background(), server.URL+"/master.m3u8", &buf); err != nil {
+		t.Fatal(err)
+	}
+	checkMuxedStream(t, buf.Bytes(), []string{"v0", "a0", "v1", "a1"})
+
+	// fragmented MP4 is saved by its container
+	tweet := &Tweet{ID: "100", Username: "user", Videos: []Video{{ID: "1", HLSURL: server.URL + "/master.m3u8"}}}
+	dir := t.TempDir()
+	results := New().NewDownloader().WithDir(dir).Download(context.Background(), tweet)
+	if results[0].Error != nil {
+		t.Fatal(results[0].Error)
+	}
+	if expected := filepath.Join(dir, "user_100_1.mp4"); results[0].Path != expected {
+		t.Errorf("Expected path %s, got %s", expected, results[0].Path)
+	}
+	data, err := os.ReadFile(results[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMuxedStream(t, data, []string{"v0", "a0", "v1", "a1"})
+}
@@ -0,0 +1,457 @@
+package twitterscraper
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HLSVariant is a rendition of HLS master playlist
+type HLSVariant struct {
+	// Audio is group ID of separate audio renditions
+	Audio     string
+	Bandwidth int
+	Codecs    string
+	Height    int
+	URL       string
+	Width     int
+}
+
+type hlsPlaylist struct {
+	// default audio rendition URL by group ID
+	audio map[string]string
+	// ended is set for VOD playlists and live ones with EXT-X-ENDLIST
+	ended bool
+	// initialization section (EXT-X-MAP) of fragmented MP4 streams
+	initURL string
+	// media sequence number of the first segment
+	sequence       int64
+	segments       []string
+	targetDuration time.Duration
+	variants       []HLSVariant
+}
+
+// hlsTrack is media playlist being downloaded
+type hlsTrack struct {
+	url      string
+	playlist *hlsPlaylist
+	// media sequence number of the next segment to download
+	next int64
+}
+
+// hlsStream is media playlist of the chosen rendition and its separate audio
+type hlsStream struct {
+	audio *hlsTrack
+	video *hlsTrack
+}
+
+// live playlists are reloaded until EXT-X-ENDLIST or this number of reloads without new segments
+const hlsLiveReloads = 10
+
+// WithMaxBandwidth limits bandwidth (bits per second) of HLS rendition
+func (d *Downloader) WithMaxBandwidth(bandwidth int) *Downloader {
+	d.maxBandwidth = bandwidth
+	return d
+}
+
+// WithMaxHeight limits vertical resolution of HLS rendition
+func (d *Downloader) WithMaxHeight(height int) *Downloader {
+	d.maxHeight = height
+	return d
+}
+
+// WithRetries set number of retries for failed HLS segments
+func (d *Downloader) WithRetries(n int) *Downloader {
+	if n >= 0 {
+		d.retries = n
+	}
+	return d
+}
+
+// DownloadHLS writes HLS stream (tweet video, Space replay or broadcast) to w.
+// For master playlist the best rendition within bandwidth and height limits is taken.
+// Segments are downloaded in parallel and concatenated into a single stream:
+// MPEG-TS, or fragmented MP4 when the stream has initialization section (EXT-X-MAP).
+// Separate audio rendition of fragmented MP4 is muxed as the second track.
+// Live playlists are reloaded until the stream ends.
+func (d *Downloader) DownloadHLS(ctx context.Context, playlistURL string, w io.Writer) error {
+	stream, err := d.openHLS(ctx, playlistURL)
+	if err != nil {
+		return err
+	}
+	return d.writeHLS(ctx, stream, w)
+}
+
+// openHLS fetches media playlists of the best rendition and its audio
+func (d *Downloader) openHLS(ctx context.Context, playlistURL string) (*hlsStream, error) {
+	track, err := d.openTrack(ctx, playlistURL)
+	if err != nil {
+		return nil, err
+	}
+	stream := &hlsStream{video: track}
+	if len(track.playlist.variants) == 0 {
+		return stream, nil
+	}
+
+	variant := d.selectVariant(track.playlist.variants)
+	audioURL := track.playlist.audio[variant.Audio]
+	if stream.video, err = d.openTrack(ctx, variant.URL); err != nil {
+		return nil, err
+	}
+	if audioURL != "" && audioURL != variant.URL {
+		if stream.audio, err = d.openTrack(ctx, audioURL); err != nil {
+			return nil, err
+		}
+		if stream.video.playlist.initURL == "" || stream.audio.playlist.initURL == "" {
+			return nil, errors.New("separate audio rendition is supported only for fragmented MP4")
+		}
+	}
+	return stream, nil
+}
+
+func (d *Downloader) openTrack(ctx context.Context, playlistURL string) (*hlsTrack, error) {
+	playlist, err := d.fetchPlaylist(ctx, playlistURL)
+	if err != nil {
+		return nil, err
+	}
+	return &hlsTrack{url: playlistURL, playlist: playlist, next: playlist.sequence}, nil
+}
+
+// ext returns file extension of the stream container
+func (stream *hlsStream) ext() string {
+	if stream.video.playlist.initURL != "" {
+		return "mp4"
+	}
+	if segments := stream.video.playlist.segments; len(segments) > 0 {
+		switch ext := mediaExt(segments[0]); ext {
+		case "aac", "mp3":
+			return ext
+		}
+	}
+	return "ts"
+}
+
+func (stream *hlsStream) ended() bool {
+	return stream.video.playlist.ended && (stream.audio == nil || stream.audio.playlist.ended)
+}
+
+// pending returns segments after the downloaded ones
+func (track *hlsTrack) pending() []string {
+	skip := track.next - track.playlist.sequence
+	if skip < 0 {
+		// segments are gone from the window of live playlist
+		skip = 0
+	}
+	if skip >= int64(len(track.playlist.segments)) {
+		return nil
+	}
+	track.next = track.playlist.sequence + int64(len(track.playlist.segments))
+	return track.playlist.segments[skip:]
+}
+
+func (d *Downloader) writeHLS(ctx context.Context, stream *hlsStream, w io.Writer) error {
+	var muxer *fmp4Muxer
+	if stream.audio != nil {
+		videoInit, err := d.fetchSegment(ctx, stream.video.playlist.initURL)
+		if err != nil {
+			return err
+		}
+		audioInit, err := d.fetchSegment(ctx, stream.audio.playlist.initURL)
+		if err != nil {
+			return err
+		}
+		if muxer, err = newFMP4Muxer(w, videoInit, audioInit); err != nil {
+			return err
+		}
+	} else if stream.video.playlist.initURL != "" {
+		data, err := d.fetchSegment(ctx, stream.video.playlist.initURL)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+
+	written, idle := 0, 0
+	for {
+		video := stream.video.pending()
+		var audio []string
+		if stream.audio != nil {
+			audio = stream.audio.pending()
+		}
+		if err := d.writeSegments(ctx, w, muxer, written, video, audio); err != nil {
+			return err
+		}
+		written += len(video)
+
+		if stream.ended() {
+			if written == 0 {
+				return errors.New("HLS playlist has no segments")
+			}
+			return nil
+		}
+		// live playlist is reloaded after target duration, or half of it when unchanged
+		wait := stream.video.playlist.targetDuration
+		if wait <= 0 {
+			wait = time.Second
+		}
+		if len(video)+len(audio) == 0 {
+			idle++
+			if idle >= hlsLiveReloads {
+				return nil
+			}
+			wait /= 2
+		} else {
+			idle = 0
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		if err := d.reloadTrack(ctx, stream.video); err != nil {
+			return err
+		}
+		if stream.audio != nil {
+			if err := d.reloadTrack(ctx, stream.audio); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (d *Downloader) reloadTrack(ctx context.Context, track *hlsTrack) error {
+	playlist, err := d.fetchPlaylist(ctx, track.url)
+	if err != nil {
+		return err
+	}
+	track.playlist = playlist
+	return nil
+}
+
+// writeSegments fetches segments in batches to keep memory bounded and the order intact,
+// audio segments are interleaved with the video ones
+func (d *Downloader) writeSegments(ctx context.Context, w io.Writer, muxer *fmp4Muxer, first int, video, audio []string) error {
+	count := len(video)
+	if len(audio) > count {
+		count = len(audio)
+	}
+	for start := 0; start < count; start += d.concurrency {
+		end := start + d.concurrency
+		if end > count {
+			end = count
+		}
+		videoData, videoErrs := d.fetchBatch(ctx, video, start, end)
+		audioData, audioErrs := d.fetchBatch(ctx, audio, start, end)
+		for i := 0; i < end-start; i++ {
+			if videoErrs[i] != nil {
+				return fmt.Errorf("segment %d: %v", first+start+i, videoErrs[i])
+			}
+			if audioErrs[i] != nil {
+				return fmt.Errorf("audio segment %d: %v", first+start+i, audioErrs[i])
+			}
+			var err error
+			switch {
+			case videoData[i] == nil:
+			case muxer != nil:
+				err = muxer.writeVideo(videoData[i])
+			default:
+				_, err = w.Write(videoData[i])
+			}
+			if err == nil && audioData[i] != nil {
+				err = muxer.writeAudio(audioData[i])
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fetchBatch downloads segments[start:end] in parallel, missing segments are nil
+func (d *Downloader) fetchBatch(ctx context.Context, segments []string, start, end int) ([][]byte, []error) {
+	batch := make([][]byte, end-start)
+	errs := make([]error, end-start)
+	var wg sync.WaitGroup
+	for i := start; i < end && i < len(segments); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			batch[i-start], errs[i-start] = d.fetchSegment(ctx, segments[i])
+		}(i)
+	}
+	wg.Wait()
+	return batch, errs
+}
+
+// selectVariant returns rendition with the highest bandwidth within the limits,
+// the lowest one if none fits
+func (d *Downloader) selectVariant(variants []HLSVariant) HLSVariant {
+	var best, lowest *HLSVariant
+	for i := range variants {
+		variant := &variants[i]
+		if lowest == nil || variant.Bandwidth < lowest.Bandwidth {
+			lowest = variant
+		}
+		if d.maxBandwidth > 0 && variant.Bandwidth > d.maxBandwidth {
+			continue
+		}
+		if d.maxHeight > 0 && variant.Height > d.maxHeight {
+			continue
+		}
+		if best == nil || variant.Bandwidth > best.Bandwidth {
+			best = variant
+		}
+	}
+	if best == nil {
+		return *lowest
+	}
+	return *best
+}
+
+func (d *Downloader) fetchPlaylist(ctx context.Context, playlistURL string) (*hlsPlaylist, error) {
+	data, err := d.fetchSegment(ctx, playlistURL)
+	if err != nil {
+		return nil, err
+	}
+	base, err := url.Parse(playlistURL)
+	if err != nil {
+		return nil, err
+	}
+	return parsePlaylist(base, string(data))
+}
+
+// fetchSegment gets content of URL with retries
+func (d *Downloader) fetchSegment(ctx context.Context, segmentURL string) ([]byte, error) {
+	var err error
+	for attempt := 0; attempt <= d.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Duration(attempt) * 500 * time.Millisecond):
+			}
+		}
+		var data []byte
+		data, err = d.get(ctx, segmentURL)
+		if err == nil {
+			return data, nil
+		}
+	}
+	return nil, err
+}
+
+func (d *Downloader) get(ctx context.Context, u string) ([]byte, error) {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.httpClient().Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("response status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// parsePlaylist parses HLS master or media playlist
+func parsePlaylist(base *url.URL, content string) (*hlsPlaylist, error) {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != "#EXTM3U" {
+		return nil, errors.New("invalid HLS playlist")
+	}
+	resolve := func(ref string) string {
+		u, err := url.Parse(ref)
+		if err != nil {
+			return ref
+		}
+		return base.ResolveReference(u).String()
+	}
+
+	playlist := &hlsPlaylist{audio: make(map[string]string)}
+	var variant *HLSVariant
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#EXT-X-STREAM-INF:"):
+			attrs := parseAttributes(strings.TrimPrefix(line, "#EXT-X-STREAM-INF:"))
+			variant = &HLSVariant{Audio: attrs["AUDIO"], Codecs: attrs["CODECS"]}
+			variant.Bandwidth, _ = strconv.Atoi(attrs["BANDWIDTH"])
+			if resolution := strings.SplitN(attrs["RESOLUTION"], "x", 2); len(resolution) == 2 {
+				variant.Width, _ = strconv.Atoi(resolution[0])
+				variant.Height, _ = strconv.Atoi(resolution[1])
+			}
+		case strings.HasPrefix(line, "#EXT-X-MEDIA:"):
+			attrs := parseAttributes(strings.TrimPrefix(line, "#EXT-X-MEDIA:"))
+			group := attrs["GROUP-ID"]
+			// the default rendition of the group, or the first one
+			if attrs["TYPE"] == "AUDIO" && attrs["URI"] != "" && (playlist.audio[group] == "" || attrs["DEFAULT"] == "YES") {
+				playlist.audio[group] = resolve(attrs["URI"])
+			}
+		case strings.HasPrefix(line, "#EXT-X-MEDIA-SEQUENCE:"):
+			playlist.sequence, _ = strconv.ParseInt(strings.TrimPrefix(line, "#EXT-X-MEDIA-SEQUENCE:"), 10, 64)
+		case strings.HasPrefix(line, "#EXT-X-TARGETDURATION:"):
+			seconds, _ := strconv.Atoi(strings.TrimPrefix(line, "#EXT-X-TARGETDURATION:"))
+			playlist.targetDuration = time.Duration(seconds) * time.Second
+		case line == "#EXT-X-ENDLIST", line == "#EXT-X-PLAYLIST-TYPE:VOD":
+			playlist.ended = true
+		case strings.HasPrefix(line, "#EXT-X-MAP:"):
+			playlist.initURL = resolve(parseAttributes(strings.TrimPrefix(line, "#EXT-X-MAP:"))["URI"])
+		case strings.HasPrefix(line, "#EXT-X-KEY:"):
+			if method := parseAttributes(strings.TrimPrefix(line, "#EXT-X-KEY:"))["METHOD"]; method != "NONE" {
+				return nil, fmt.Errorf("encrypted HLS (%s) is not supported", method)
+			}
+		case strings.HasPrefix(line, "#"):
+		case variant != nil:
+			variant.URL = resolve(line)
+			playlist.variants = append(playlist.variants, *variant)
+			variant = nil
+		default:
+			playlist.segments = append(playlist.segments, resolve(line))
+		}
+	}
+	return playlist, scanner.Err()
+}
+
+// parseAttributes parses attribute list like BANDWIDTH=1280000,CODECS="avc1.4d401f,mp4a.40.2"
+func parseAttributes(list string) map[string]string {
+	attrs := make(map[string]string)
+	for list != "" {
+		eq := strings.IndexByte(list, '=')
+		if eq < 0 {
+			break
+		}
+		key := strings.TrimSpace(list[:eq])
+		list = list[eq+1:]
+		var value string
+		if strings.HasPrefix(list, `"`) {
+			end := strings.IndexByte(list[1:], '"')
+			if end < 0 {
+				value, list = list[1:], ""
+			} else {
+				value, list = list[1:end+1], list[end+2:]
+			}
+		} else if comma := strings.IndexByte(list, ','); comma >= 0 {
+			value, list = list[:comma], list[comma:]
+		} else {
+			value, list = list, ""
+		}
+		attrs[key] = value
+		list = strings.TrimPrefix(list, ",")
+	}
+	return attrs
+}
@@ -0,0 +1,130 @@
+package twitterscraper_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+// newHLSServer serves testdata/hls fixtures, the first request of each seg1.ts fails
+func newHLSServer() *httptest.Server {
+	files := http.StripPrefix("/hls/", http.FileServer(http.Dir("testdata/hls")))
+	var mu sync.Mutex
+	failed := make(map[string]bool)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/seg1.ts") {
+			mu.Lock()
+			fail := !failed[r.URL.Path]
+			failed[r.URL.Path] = true
+			mu.Unlock()
+			if fail {
+				http.Error(w, "temporary error", http.StatusServiceUnavailable)
+				return
+			}
+		}
+		files.ServeHTTP(w, r)
+	}))
+}
+
+func TestDownloadHLS(t *testing.T) {
+	tests := []struct {
+		name       string
+		downloader func(*twitterscraper.Downloader) *twitterscraper.Downloader
+		expected   string
+	}{
+		{"best", func(d *twitterscraper.Downloader) *twitterscraper.Downloader { return d }, "720"},
+		{"max height", func(d *twitterscraper.Downloader) *twitterscraper.Downloader { return d.WithMaxHeight(480) }, "360"},
+		{"max bandwidth", func(d *twitterscraper.Downloader) *twitterscraper.Downloader { return d.WithMaxBandwidth(300000) }, "270"},
+		{"lowest", func(d *twitterscraper.Downloader) *twitterscraper.Downloader { return d.WithMaxBandwidth(1) }, "270"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newHLSServer()
+			defer server.Close()
+
+			var buf bytes.Buffer
+			downloader := test.downloader(twitterscraper.New().NewDownloader().WithConcurrency(2))
+			if err := downloader.DownloadHLS(context.Background(), server.URL+"/hls/master.m3u8", &buf); err != nil {
+				t.Fatal(err)
+			}
+			expected := strings.Replace("R-0;R-1;R-2;R-3;", "R", test.expected, -1)
+			if buf.String() != expected {
+				t.Errorf("Expected stream %q, got %q", expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestDownloadHLSWithoutRetries(t *testing.T) {
+	server := newHLSServer()
+	defer server.Close()
+
+	var buf bytes.Buffer
+	downloader := twitterscraper.New().NewDownloader().WithRetries(0)
+	if err := downloader.DownloadHLS(context.Background(), server.URL+"/hls/720/playlist.m3u8", &buf); err == nil {
+		t.Error("Expected error for failed segment")
+	}
+}
+
+func TestDownloaderHLSVideo(t *testing.T) {
+	server := newHLSServer()
+	defer server.Close()
+
+	tweet := &twitterscraper.Tweet{
+		ID:       "100",
+		Username: "user",
+		Videos: []twitterscraper.Video{{
+			ID:     "1",
+			HLSURL: server.URL + "/hls/master.m3u8",
+			Variants: []twitterscraper.Variant{
+				{ContentType: "application/x-mpegURL", URL: server.URL + "/hls/master.m3u8"},
+			},
+		}},
+	}
+	dir := t.TempDir()
+	results := twitterscraper.New().NewDownloader().WithDir(dir).Download(context.Background(), tweet)
+	if results[0].Error != nil {
+		t.Fatal(results[0].Error)
+	}
+	assertFile(t, filepath.Join(dir, "user_100_1.ts"), []byte("720-0;720-1;720-2;720-3;"))
+}
+
+func TestDownloadHLSLive(t *testing.T) {
+	// the live playlist grows on each reload, then slides and ends
+	playlists := []string{
+		"#EXTM3U\n#EXT-X-TARGETDURATION:1\n#EXT-X-MEDIA-SEQUENCE:0\n#EXTINF:1.0,\nseg0.ts\n#EXTINF:1.0,\nseg1.ts\n",
+		"#EXTM3U\n#EXT-X-TARGETDURATION:1\n#EXT-X-MEDIA-SEQUENCE:0\n#EXTINF:1.0,\nseg0.ts\n#EXTINF:1.0,\nseg1.ts\n#EXTINF:1.0,\nseg2.ts\n",
+		"#EXTM3U\n#EXT-X-TARGETDURATION:1\n#EXT-X-MEDIA-SEQUENCE:2\n#EXTINF:1.0,\nseg2.ts\n#EXTINF:1.0,\nseg3.ts\n#EXT-X-ENDLIST\n",
+	}
+	var mu sync.Mutex
+	reloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/live.m3u8" {
+			mu.Lock()
+			playlist := playlists[reloads]
+			if reloads < len(playlists)-1 {
+				reloads++
+			}
+			mu.Unlock()
+			w.Write([]byte(playlist))
+			return
+		}
+		w.Write([]byte(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/seg"), ".ts") + ";"))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	if err := twitterscraper.New().NewDownloader().DownloadHLS(context.Background(), server.URL+"/live.m3u8", &buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "0;1;2;3;" {
+		t.Errorf("Expected all segments of live stream once, got %q", buf.String())
+	}
+}
@@ -0,0 +1,76 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+const (
+	homeTimelineURL       = "https://twitter.com/i/api/graphql/HJFjzBgCs16TqxewQOeLNg/HomeTimeline"
+	homeLatestTimelineURL = "https://twitter.com/i/api/graphql/K0X1xbCZUjttdK8RazKAlw/HomeLatestTimeline"
+)
+
+type homeTimeline struct {
+	Data struct {
+		Home struct {
+			HomeTimelineUrt struct {
+				Instructions []instruction `json:"instructions"`
+			} `json:"home_timeline_urt"`
+		} `json:"home"`
+	} `json:"data"`
+}
+
+// GetHomeTimeline returns channel with tweets from the home timeline of the logged in account.
+// Set latest to get the "Following" timeline in chronological order instead of "For You".
+func (s *Scraper) GetHomeTimeline(ctx context.Context, maxTweetsNbr int, latest bool) <-chan *TweetResult {
+	return getTweetTimeline(ctx, "", maxTweetsNbr, timelineFilter{}, func(_ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.FetchHomeTweets(maxTweetsNbr, cursor, latest)
+	})
+}
+
+// FetchHomeTweets gets tweets from the home timeline of the logged in account, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchHomeTweets(maxTweetsNbr int, cursor string, latest bool) ([]*Tweet, string, error) {
+	if !s.isLogged {
+		return nil, "", errors.New("scraper is not logged in for home timeline")
+	}
+
+	if maxTweetsNbr > 100 {
+		maxTweetsNbr = 100
+	}
+
+	endpoint := homeTimelineURL
+	if latest {
+		endpoint = homeLatestTimelineURL
+	}
+	req, err := s.newRequest("GET", endpoint)
+	if err != nil {
+		return nil, "", err
+	}
+
+	variables := map[string]interface{}{
+		"count":                  maxTweetsNbr,
+		"includePromotedContent": s.includePromoted,
+		"latestControlAvailable": true,
+		"requestContext":         "launch",
+		"withCommunity":          true,
+	}
+
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(timelineFeatures()))
+	req.URL.RawQuery = query.Encode()
+
+	var timeline homeTimeline
+	err = s.RequestAPI(req, &timeline)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tweets, nextCursor := parseTimelineTweets(timeline.Data.Home.HomeTimelineUrt.Instructions, s.includePromoted)
+	return tweets, nextCursor, nil
+}
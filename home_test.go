@@ -0,0 +1,33 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetHomeTimeline(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	for _, latest := range []bool{false, true} {
+		count := 0
+		maxTweetsNbr := 40
+		dupcheck := make(map[string]bool)
+		for tweet := range testScraper.GetHomeTimeline(context.Background(), maxTweetsNbr, latest) {
+			if tweet.Error != nil {
+				t.Fatal(tweet.Error)
+			}
+			count++
+			if tweet.ID == "" {
+				t.Error("Expected tweet ID is empty")
+			} else if dupcheck[tweet.ID] {
+				t.Errorf("Detect duplicated tweet ID: %s", tweet.ID)
+			} else {
+				dupcheck[tweet.ID] = true
+			}
+		}
+		if count != maxTweetsNbr {
+			t.Errorf("Expected tweets count=%v, got: %v (latest=%v)", maxTweetsNbr, count, latest)
+		}
+	}
+}
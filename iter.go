@@ -0,0 +1,132 @@
+//go:build go1.23
+
+package twitterscraper
+
+import (
+	"context"
+	"iter"
+)
+
+// TweetsIter returns iterator over tweets of the user.
+// Pages are fetched when the loop asks for more, breaking the loop stops the pagination.
+func (s *Scraper) TweetsIter(ctx context.Context, user string, maxTweetsNbr int) iter.Seq2[*Tweet, error] {
+	return func(yield func(*Tweet, error) bool) {
+		paginator := NewTweetPaginator(user, s.FetchTweets).WithPageSize(maxTweetsNbr)
+		tweetsIter(ctx, paginator, maxTweetsNbr, timelineFilter{})(yield)
+	}
+}
+
+// TweetsIterWithOptions returns iterator over tweets of the user, filtered by options.
+// The timeline is over on the first tweet older than opts.Since.
+func (s *Scraper) TweetsIterWithOptions(ctx context.Context, user string, maxTweetsNbr int, opts TimelineOptions) iter.Seq2[*Tweet, error] {
+	return func(yield func(*Tweet, error) bool) {
+		paginator := NewTweetPaginator(user, s.FetchTweets).WithPageSize(maxTweetsNbr)
+		tweetsIter(ctx, paginator, maxTweetsNbr, opts.timelineFilter(true))(yield)
+	}
+}
+
+// SearchIter returns iterator over tweets for a given search query
+func (s *Scraper) SearchIter(ctx context.Context, query string, maxTweetsNbr int) iter.Seq2[*Tweet, error] {
+	return func(yield func(*Tweet, error) bool) {
+		paginator := NewTweetPaginator(query, s.FetchSearchTweets).WithPageSize(maxTweetsNbr)
+		tweetsIter(ctx, paginator, maxTweetsNbr, timelineFilter{})(yield)
+	}
+}
+
+// SearchProfilesIter returns iterator over profiles for a given search query
+func (s *Scraper) SearchProfilesIter(ctx context.Context, query string, maxProfilesNbr int) iter.Seq2[*Profile, error] {
+	return func(yield func(*Profile, error) bool) {
+		paginator := NewProfilePaginator(query, s.FetchSearchProfiles).WithPageSize(maxProfilesNbr)
+		paginator.Profiles(ctx, maxProfilesNbr)(yield)
+	}
+}
+
+// Tweets returns iterator over the rest of tweets timeline, 0 for no limit.
+// Only the yielded tweets are consumed, so the next range continues from the first unseen one.
+func (p *Paginator) Tweets(ctx context.Context, maxTweetsNbr int) iter.Seq2[*Tweet, error] {
+	return tweetsIter(ctx, p, maxTweetsNbr, timelineFilter{})
+}
+
+// TweetsWithOptions returns iterator over the rest of chronological tweets timeline, filtered by options.
+// The pagination is over on the first tweet older than opts.Since.
+func (p *Paginator) TweetsWithOptions(ctx context.Context, maxTweetsNbr int, opts TimelineOptions) iter.Seq2[*Tweet, error] {
+	return tweetsIter(ctx, p, maxTweetsNbr, opts.timelineFilter(true))
+}
+
+func tweetsIter(ctx context.Context, p *Paginator, maxTweetsNbr int, filter timelineFilter) iter.Seq2[*Tweet, error] {
+	return func(yield func(*Tweet, error) bool) {
+		for count := 0; p.HasNext() && (maxTweetsNbr <= 0 || count < maxTweetsNbr); {
+			page, err := p.peek(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, tweet := range page.Tweets {
+				keep, stop := filter.match(tweet)
+				if stop {
+					// the rest of timeline is out of bounds, so checkpoint is done too
+					p.done = true
+					return
+				}
+				if !keep {
+					p.consume(1)
+					continue
+				}
+				if maxTweetsNbr > 0 && count >= maxTweetsNbr {
+					return
+				}
+				p.consume(1)
+				if !yield(tweet, nil) {
+					return
+				}
+				count++
+			}
+		}
+	}
+}
+
+// Profiles returns iterator over the rest of profiles timeline, 0 for no limit
+func (p *Paginator) Profiles(ctx context.Context, maxProfilesNbr int) iter.Seq2[*Profile, error] {
+	return func(yield func(*Profile, error) bool) {
+		for count := 0; p.HasNext() && (maxProfilesNbr <= 0 || count < maxProfilesNbr); {
+			page, err := p.peek(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, profile := range page.Profiles {
+				if maxProfilesNbr > 0 && count >= maxProfilesNbr {
+					return
+				}
+				p.consume(1)
+				if !yield(profile, nil) {
+					return
+				}
+				count++
+			}
+		}
+	}
+}
+
+// Lists returns iterator over the rest of lists timeline, 0 for no limit
+func (p *Paginator) Lists(ctx context.Context, maxListsNbr int) iter.Seq2[*List, error] {
+	return func(yield func(*List, error) bool) {
+		for count := 0; p.HasNext() && (maxListsNbr <= 0 || count < maxListsNbr); {
+			page, err := p.peek(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, list := range page.Lists {
+				if maxListsNbr > 0 && count >= maxListsNbr {
+					return
+				}
+				p.consume(1)
+				if !yield(list, nil) {
+					return
+				}
+				count++
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+//go:build go1.23
+
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestPaginatorTweetsIter(t *testing.T) {
+	calls := 0
+	fetch := fetchPages(5)
+	paginator := twitterscraper.NewTweetPaginator("query", func(query string, maxTweetsNbr int, cursor string) ([]*twitterscraper.Tweet, string, error) {
+		calls++
+		return fetch(query, maxTweetsNbr, cursor)
+	})
+	var ids []string
+	for tweet, err := range paginator.Tweets(context.Background(), 0) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, tweet.ID)
+		if len(ids) == 3 {
+			break
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 pages fetched, got %d", calls)
+	}
+	if checkpoint := paginator.Checkpoint(); checkpoint.Cursor != "1" || checkpoint.Offset != 1 || checkpoint.Count != 3 {
+		t.Errorf("Unexpected checkpoint %+v", checkpoint)
+	}
+	// the rest of timeline from the first unseen tweet
+	for tweet, err := range paginator.Tweets(context.Background(), 0) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, tweet.ID)
+	}
+	if fmt.Sprint(ids) != "[0 1 2 3 4 5 6 7 8 9]" {
+		t.Errorf("Unexpected tweets %v", ids)
+	}
+	if calls != 5 {
+		t.Errorf("Expected 5 pages fetched, got %d", calls)
+	}
+}
+
+func TestPaginatorTweetsIterLimit(t *testing.T) {
+	calls := 0
+	fetch := fetchPages(5)
+	paginator := twitterscraper.NewTweetPaginator("query", func(query string, maxTweetsNbr int, cursor string) ([]*twitterscraper.Tweet, string, error) {
+		calls++
+		return fetch(query, maxTweetsNbr, cursor)
+	})
+	count := 0
+	for _, err := range paginator.Tweets(context.Background(), 4) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		count++
+	}
+	if count != 4 || calls != 2 {
+		t.Errorf("Expected 4 tweets of 2 pages, got %d tweets of %d pages", count, calls)
+	}
+}
+
+func TestPaginatorTweetsIterSince(t *testing.T) {
+	// tweets of page 3 and older are created before since
+	since := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	calls := 0
+	fetch := fetchPages(5)
+	paginator := twitterscraper.NewTweetPaginator("query", func(query string, maxTweetsNbr int, cursor string) ([]*twitterscraper.Tweet, string, error) {
+		calls++
+		tweets, next, err := fetch(query, maxTweetsNbr, cursor)
+		for _, tweet := range tweets {
+			id, _ := strconv.Atoi(tweet.ID)
+			tweet.TimeParsed = since.Add(time.Duration(5-id) * time.Hour)
+		}
+		return tweets, next, err
+	})
+	opts := twitterscraper.TimelineOptions{Since: since}
+	var ids []string
+	for tweet, err := range paginator.TweetsWithOptions(context.Background(), 0, opts) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, tweet.ID)
+	}
+	if fmt.Sprint(ids) != "[0 1 2 3 4 5]" {
+		t.Errorf("Unexpected tweets %v", ids)
+	}
+	if paginator.HasNext() || !paginator.Checkpoint().Done {
+		t.Errorf("Expected pagination is done on tweet before since, got %+v", paginator.Checkpoint())
+	}
+	// the next range doesn't page past the bound
+	for range paginator.TweetsWithOptions(context.Background(), 0, opts) {
+		t.Error("Expected no tweets after the pagination is done")
+	}
+	if calls != 4 {
+		t.Errorf("Expected 4 pages fetched, got %d", calls)
+	}
+}
+
+func TestPaginatorTweetsIterError(t *testing.T) {
+	fetchErr := errors.New("rate limit")
+	paginator := twitterscraper.NewTweetPaginator("query", func(query string, maxTweetsNbr int, cursor string) ([]*twitterscraper.Tweet, string, error) {
+		return nil, "", fetchErr
+	})
+	for tweet, err := range paginator.Tweets(context.Background(), 0) {
+		if tweet != nil || err != fetchErr {
+			t.Errorf("Expected fetch error, got %v", err)
+		}
+	}
+}
+
+func TestTweetsIter(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	count := 0
+	for tweet, err := range testScraper.TweetsIter(context.Background(), "Twitter", 30) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tweet.ID == "" {
+			t.Error("Expected tweet ID is not empty")
+		}
+		count++
+	}
+	if count != 30 {
+		t.Errorf("Expected 30 tweets, got %d", count)
+	}
+}
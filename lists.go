@@ -0,0 +1,241 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+const (
+	listByRestIDURL    = "https://twitter.com/i/api/graphql/iTpgCtbdxrsJfyx0cFjHqg/ListByRestId"
+	listTweetsURL      = "https://twitter.com/i/api/graphql/2TemLyqrMpTeAmysdbnVqw/ListLatestTweetsTimeline"
+	listMembersURL     = "https://twitter.com/i/api/graphql/BQp2IEYkgxuSxqbTAr1e1g/ListMembers"
+	listSubscribersURL = "https://twitter.com/i/api/graphql/74wGEkaBxrdoXakWTWMxRQ/ListSubscribers"
+	listOwnershipsURL  = "https://twitter.com/i/api/graphql/6F6wXzGUE32r1lYWgPsXfQ/ListOwnerships"
+	listMembershipsURL = "https://twitter.com/i/api/graphql/BlEXXdARdSeL_0KyKHHvvg/ListMemberships"
+)
+
+// List of twitter users.
+type List struct {
+	Description     string
+	ID              string
+	IsPrivate       bool
+	MemberCount     int
+	Name            string
+	Owner           Profile
+	SubscriberCount int
+	URL             string
+}
+
+type listInfo struct {
+	Description     string `json:"description"`
+	IDStr           string `json:"id_str"`
+	MemberCount     int    `json:"member_count"`
+	Mode            string `json:"mode"`
+	Name            string `json:"name"`
+	SubscriberCount int    `json:"subscriber_count"`
+	UserResults     struct {
+		Result userResult `json:"result"`
+	} `json:"user_results"`
+}
+
+func (list *listInfo) parse() *List {
+	if list.IDStr == "" {
+		return nil
+	}
+	return &List{
+		Description:     list.Description,
+		ID:              list.IDStr,
+		IsPrivate:       list.Mode == "Private",
+		MemberCount:     list.MemberCount,
+		Name:            list.Name,
+		Owner:           list.UserResults.Result.parse(),
+		SubscriberCount: list.SubscriberCount,
+		URL:             "https://twitter.com/i/lists/" + list.IDStr,
+	}
+}
+
+type listTimeline struct {
+	Data struct {
+		List struct {
+			TweetsTimeline struct {
+				Timeline struct {
+					Instructions []instruction `json:"instructions"`
+				} `json:"timeline"`
+			} `json:"tweets_timeline"`
+			MembersTimeline struct {
+				Timeline struct {
+					Instructions []instruction `json:"instructions"`
+				} `json:"timeline"`
+			} `json:"members_timeline"`
+			SubscribersTimeline struct {
+				Timeline struct {
+					Instructions []instruction `json:"instructions"`
+				} `json:"timeline"`
+			} `json:"subscribers_timeline"`
+		} `json:"list"`
+		User struct {
+			Result struct {
+				Timeline struct {
+					Timeline struct {
+						Instructions []instruction `json:"instructions"`
+					} `json:"timeline"`
+				} `json:"timeline"`
+			} `json:"result"`
+		} `json:"user"`
+	} `json:"data"`
+}
+
+// GetList return list metadata by ID.
+func (s *Scraper) GetList(listID string) (*List, error) {
+	req, err := s.newRequest("GET", listByRestIDURL)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"listId": listID,
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(timelineFeatures()))
+	req.URL.RawQuery = query.Encode()
+
+	var jsn struct {
+		Data struct {
+			List listInfo `json:"list"`
+		} `json:"data"`
+	}
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, err
+	}
+
+	list := jsn.Data.List.parse()
+	if list == nil {
+		return nil, fmt.Errorf("list with ID %s not found", listID)
+	}
+	return list, nil
+}
+
+// GetListTweets returns channel with tweets of a given list.
+func (s *Scraper) GetListTweets(ctx context.Context, listID string, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, listID, maxTweetsNbr, timelineFilter{}, s.FetchListTweets)
+}
+
+// GetListTweetsWithOptions returns channel with tweets of a given list, filtered by options.
+// The timeline is over on the first tweet older than opts.Since.
+func (s *Scraper) GetListTweetsWithOptions(ctx context.Context, listID string, maxTweetsNbr int, opts TimelineOptions) <-chan *TweetResult {
+	return getTweetTimeline(ctx, listID, maxTweetsNbr, opts.timelineFilter(true), s.FetchListTweets)
+}
+
+// GetListMembers returns channel with members of a given list.
+func (s *Scraper) GetListMembers(ctx context.Context, listID string, maxProfilesNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, listID, maxProfilesNbr, s.FetchListMembers)
+}
+
+// GetListSubscribers returns channel with subscribers of a given list.
+func (s *Scraper) GetListSubscribers(ctx context.Context, listID string, maxProfilesNbr int) <-chan *ProfileResult {
+	return getUserTimeline(ctx, listID, maxProfilesNbr, s.FetchListSubscribers)
+}
+
+// GetUserLists returns channel with lists owned by a given user,
+// or lists the user is a member of if memberships is set.
+func (s *Scraper) GetUserLists(ctx context.Context, user string, maxListsNbr int, memberships bool) <-chan *ListResult {
+	if memberships {
+		return getListTimeline(ctx, user, maxListsNbr, s.FetchUserListMemberships)
+	}
+	return getListTimeline(ctx, user, maxListsNbr, s.FetchUserLists)
+}
+
+// FetchListTweets gets tweets of a given list, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchListTweets(listID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	timeline, err := s.getListTimeline(listTweetsURL, "listId", listID, maxTweetsNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	tweets, nextCursor := parseTimelineTweets(timeline.Data.List.TweetsTimeline.Timeline.Instructions, false)
+	return tweets, nextCursor, nil
+}
+
+// FetchListMembers gets members of a given list, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchListMembers(listID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	timeline, err := s.getListTimeline(listMembersURL, "listId", listID, maxProfilesNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	profiles, nextCursor := parseTimelineProfiles(timeline.Data.List.MembersTimeline.Timeline.Instructions)
+	return profiles, nextCursor, nil
+}
+
+// FetchListSubscribers gets subscribers of a given list, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchListSubscribers(listID string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	timeline, err := s.getListTimeline(listSubscribersURL, "listId", listID, maxProfilesNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	profiles, nextCursor := parseTimelineProfiles(timeline.Data.List.SubscribersTimeline.Timeline.Instructions)
+	return profiles, nextCursor, nil
+}
+
+// FetchUserLists gets lists owned by a given user, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchUserLists(user string, maxListsNbr int, cursor string) ([]*List, string, error) {
+	userID, err := s.GetUserIDByScreenName(user)
+	if err != nil {
+		return nil, "", err
+	}
+	timeline, err := s.getListTimeline(listOwnershipsURL, "userId", userID, maxListsNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	lists, nextCursor := parseTimelineLists(timeline.Data.User.Result.Timeline.Timeline.Instructions)
+	return lists, nextCursor, nil
+}
+
+// FetchUserListMemberships gets lists a given user is a member of, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchUserListMemberships(user string, maxListsNbr int, cursor string) ([]*List, string, error) {
+	userID, err := s.GetUserIDByScreenName(user)
+	if err != nil {
+		return nil, "", err
+	}
+	timeline, err := s.getListTimeline(listMembershipsURL, "userId", userID, maxListsNbr, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	lists, nextCursor := parseTimelineLists(timeline.Data.User.Result.Timeline.Timeline.Instructions)
+	return lists, nextCursor, nil
+}
+
+func (s *Scraper) getListTimeline(endpoint string, key string, id string, maxNbr int, cursor string) (*listTimeline, error) {
+	if maxNbr > 100 {
+		maxNbr = 100
+	}
+
+	req, err := s.newRequest("GET", endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		key:                        id,
+		"count":                    maxNbr,
+		"withSafetyModeUserFields": true,
+	}
+
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(timelineFeatures()))
+	req.URL.RawQuery = query.Encode()
+
+	var timeline listTimeline
+	err = s.RequestAPI(req, &timeline)
+	if err != nil {
+		return nil, err
+	}
+	return &timeline, nil
+}
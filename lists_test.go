@@ -0,0 +1,64 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetUserListsAndList(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	var listID string
+	for list := range testScraper.GetUserLists(context.Background(), "Twitter", 10, false) {
+		if list.Error != nil {
+			t.Fatal(list.Error)
+		}
+		if list.ID == "" {
+			t.Error("Expected list ID is empty")
+		}
+		if list.Owner.Username == "" {
+			t.Error("Expected list owner is empty")
+		}
+		if listID == "" && list.MemberCount > 0 {
+			listID = list.ID
+		}
+	}
+	if listID == "" {
+		t.Skip("No lists with members found")
+	}
+
+	list, err := testScraper.GetList(listID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.ID != listID {
+		t.Errorf("Expected list ID %s, got %s", listID, list.ID)
+	}
+	if list.Name == "" {
+		t.Error("Expected list Name is empty")
+	}
+
+	for tweet := range testScraper.GetListTweets(context.Background(), listID, 20) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if tweet.ID == "" {
+			t.Error("Expected tweet ID is empty")
+		}
+	}
+
+	members := 0
+	for profile := range testScraper.GetListMembers(context.Background(), listID, 20) {
+		if profile.Error != nil {
+			t.Fatal(profile.Error)
+		}
+		if profile.UserID == "" {
+			t.Error("Expected member UserID is empty")
+		}
+		members++
+	}
+	if members == 0 {
+		t.Error("Expected list members")
+	}
+}
@@ -0,0 +1,140 @@
+package twitterscraper
+
+import (
+	"strings"
+	"time"
+)
+
+type legacyMedia struct {
+	DisplayURL               string `json:"display_url"`
+	ExpandedURL              string `json:"expanded_url"`
+	ExtAltText               string `json:"ext_alt_text"`
+	ExtSensitiveMediaWarning struct {
+		AdultContent    bool `json:"adult_content"`
+		GraphicViolence bool `json:"graphic_violence"`
+		Other           bool `json:"other"`
+	} `json:"ext_sensitive_media_warning"`
+	Ext struct {
+		MediaStats struct {
+			R struct {
+				Ok struct {
+					ViewCount flexInt `json:"viewCount"`
+				} `json:"ok"`
+			} `json:"r"`
+		} `json:"mediaStats"`
+	} `json:"ext"`
+	IDStr      string `json:"id_str"`
+	Indices    [2]int `json:"indices"`
+	MediaStats struct {
+		ViewCount flexInt `json:"viewCount"`
+	} `json:"mediaStats"`
+	MediaURLHttps string `json:"media_url_https"`
+	OriginalInfo  struct {
+		Height int `json:"height"`
+		Width  int `json:"width"`
+	} `json:"original_info"`
+	Type      string `json:"type"`
+	URL       string `json:"url"`
+	VideoInfo struct {
+		DurationMillis int `json:"duration_millis"`
+		Variants       []struct {
+			Bitrate     int    `json:"bitrate"`
+			ContentType string `json:"content_type"`
+			URL         string `json:"url"`
+		} `json:"variants"`
+	} `json:"video_info"`
+}
+
+func (media *legacyMedia) sensitivity() MediaSensitivity {
+	return MediaSensitivity{
+		AdultContent:    media.ExtSensitiveMediaWarning.AdultContent,
+		GraphicViolence: media.ExtSensitiveMediaWarning.GraphicViolence,
+		Other:           media.ExtSensitiveMediaWarning.Other,
+	}
+}
+
+func (media *legacyMedia) variants() []Variant {
+	var variants []Variant
+	for _, variant := range media.VideoInfo.Variants {
+		variants = append(variants, Variant{
+			Bitrate:     variant.Bitrate,
+			ContentType: variant.ContentType,
+			URL:         variant.URL,
+		})
+	}
+	return variants
+}
+
+// parseMedia fills photos, videos and GIFs of the tweet
+func parseMedia(tw *Tweet, tweet *legacyTweet) {
+	for _, media := range tweet.ExtendedEntities.Media {
+		switch media.Type {
+		case "photo":
+			tw.Photos = append(tw.Photos, Photo{
+				AltText:   media.ExtAltText,
+				Height:    media.OriginalInfo.Height,
+				ID:        media.IDStr,
+				Sensitive: media.sensitivity(),
+				URL:       media.MediaURLHttps,
+				Width:     media.OriginalInfo.Width,
+			})
+		case "video":
+			video := Video{
+				AltText:   media.ExtAltText,
+				Duration:  time.Duration(media.VideoInfo.DurationMillis) * time.Millisecond,
+				Height:    media.OriginalInfo.Height,
+				ID:        media.IDStr,
+				Preview:   media.MediaURLHttps,
+				Sensitive: media.sensitivity(),
+				Variants:  media.variants(),
+				ViewCount: int(media.MediaStats.ViewCount),
+				Width:     media.OriginalInfo.Width,
+			}
+			if video.ViewCount == 0 {
+				video.ViewCount = int(media.Ext.MediaStats.R.Ok.ViewCount)
+			}
+
+			maxBitrate := 0
+			for _, variant := range video.Variants {
+				if variant.ContentType == "application/x-mpegURL" {
+					video.HLSURL = variant.URL
+				} else if variant.Bitrate > maxBitrate {
+					video.URL = strings.TrimSuffix(variant.URL, "?tag=10")
+					maxBitrate = variant.Bitrate
+				}
+			}
+
+			tw.Videos = append(tw.Videos, video)
+		case "animated_gif":
+			gif := GIF{
+				AltText:   media.ExtAltText,
+				Height:    media.OriginalInfo.Height,
+				ID:        media.IDStr,
+				Preview:   media.MediaURLHttps,
+				Sensitive: media.sensitivity(),
+				Variants:  media.variants(),
+				Width:     media.OriginalInfo.Width,
+			}
+
+			// Twitter's API doesn't provide bitrate for GIFs, (it's always set to zero).
+			// Therefore we check for `>=` instead of `>` in the loop below.
+			// Also, GIFs have just a single variant today. Just in case that changes in the future,
+			// and there will be multiple variants, we'll pick the one with the highest bitrate,
+			// if other one will have a non-zero bitrate.
+			maxBitrate := 0
+			for _, variant := range gif.Variants {
+				if variant.Bitrate >= maxBitrate {
+					gif.URL = variant.URL
+					maxBitrate = variant.Bitrate
+				}
+			}
+
+			tw.GIFs = append(tw.GIFs, gif)
+		}
+
+		if !tw.SensitiveContent {
+			sensitive := media.ExtSensitiveMediaWarning
+			tw.SensitiveContent = sensitive.AdultContent || sensitive.GraphicViolence || sensitive.Other
+		}
+	}
+}
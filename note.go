@@ -0,0 +1,62 @@
+package twitterscraper
+
+// NoteStatus is rating status of community note, shown by visual style of the note.
+// It is empty when the response has no known style.
+type NoteStatus string
+
+const (
+	// NoteRatedHelpful - note is rated helpful and shown to everyone
+	NoteRatedHelpful NoteStatus = "CurrentlyRatedHelpful"
+	// NoteNeedsMoreRatings - note is shown only to contributors for rating
+	NoteNeedsMoreRatings NoteStatus = "NeedsMoreRatings"
+)
+
+// note statuses by visual style of the pivot
+var noteStatuses = map[string]NoteStatus{
+	"Default":   NoteRatedHelpful,
+	"Tentative": NoteNeedsMoreRatings,
+}
+
+type birdwatchPivot struct {
+	Note struct {
+		RestID       string `json:"rest_id"`
+		LegacyRestID string `json:"restId"`
+	} `json:"note"`
+	Subtitle struct {
+		Text     string `json:"text"`
+		Entities []struct {
+			FromIndex int `json:"fromIndex"`
+			ToIndex   int `json:"toIndex"`
+			Ref       struct {
+				Type string `json:"type"`
+				URL  string `json:"url"`
+			} `json:"ref"`
+		} `json:"entities"`
+	} `json:"subtitle"`
+	VisualStyle string `json:"visualStyle"`
+}
+
+func (pivot *birdwatchPivot) parse() *CommunityNote {
+	if pivot.Subtitle.Text == "" {
+		return nil
+	}
+	note := &CommunityNote{
+		ID:     pivot.Note.RestID,
+		Status: noteStatuses[pivot.VisualStyle],
+		Text:   pivot.Subtitle.Text,
+	}
+	if note.ID == "" {
+		note.ID = pivot.Note.LegacyRestID
+	}
+	for _, entity := range pivot.Subtitle.Entities {
+		note.Entities = append(note.Entities, CommunityNoteEntity{
+			FromIndex: entity.FromIndex,
+			ToIndex:   entity.ToIndex,
+			URL:       entity.Ref.URL,
+		})
+		if entity.Ref.URL != "" && !stringInSlice(entity.Ref.URL, note.URLs) {
+			note.URLs = append(note.URLs, entity.Ref.URL)
+		}
+	}
+	return note
+}
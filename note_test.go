@@ -0,0 +1,62 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// loadFixture decodes JSON file of testdata
+func loadFixture(t *testing.T, name string, v interface{}) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseCommunityNote(t *testing.T) {
+	expected := &CommunityNote{
+		Entities: []CommunityNoteEntity{{FromIndex: 24, ToIndex: 40, URL: "https://example.com/2015"}},
+		ID:       "1700000000000000001",
+		Text:     "The photo is from 2015. https://t.co/abc",
+		URLs:     []string{"https://example.com/2015"},
+	}
+
+	var v2 result
+	loadFixture(t, "note/v2.json", &v2)
+	tweet := v2.parse()
+	if tweet == nil {
+		t.Fatal("Expected tweet is parsed")
+	}
+	expected.Status = NoteRatedHelpful
+	if diff := cmp.Diff(expected, tweet.CommunityNote); diff != "" {
+		t.Error("Resulting GraphQL note does not match the sample", diff)
+	}
+
+	var v1 timelineV1
+	loadFixture(t, "note/v1.json", &v1)
+	tweet = v1.parseTweet("1700000000000000000")
+	if tweet == nil {
+		t.Fatal("Expected tweet is parsed")
+	}
+	expected.Status = NoteNeedsMoreRatings
+	if diff := cmp.Diff(expected, tweet.CommunityNote); diff != "" {
+		t.Error("Resulting legacy note does not match the sample", diff)
+	}
+
+	// status isn't guessed without visual style
+	var pivot birdwatchPivot
+	if err := json.Unmarshal([]byte(`{"subtitle": {"text": "Context"}}`), &pivot); err != nil {
+		t.Fatal(err)
+	}
+	if note := pivot.parse(); note.Status != "" {
+		t.Errorf("Expected empty status, got %q", note.Status)
+	}
+}
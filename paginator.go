@@ -0,0 +1,238 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+)
+
+// Kinds of paginated items
+const (
+	PageTweets   = "tweets"
+	PageProfiles = "profiles"
+	PageLists    = "lists"
+)
+
+// Page of timeline, only the items of paginator kind are set
+type Page struct {
+	Lists    []*List
+	Profiles []*Profile
+	Tweets   []*Tweet
+	// Cursor of the next page
+	Cursor string
+}
+
+// Checkpoint of pagination, JSON encoded it allows to resume a job
+type Checkpoint struct {
+	Kind  string `json:"kind"`
+	Query string `json:"query"`
+	// Cursor of the page to continue from and Offset of items consumed in it
+	Cursor string `json:"cursor,omitempty"`
+	Offset int    `json:"offset,omitempty"`
+	// Count of items consumed so far
+	Count int  `json:"count"`
+	Done  bool `json:"done,omitempty"`
+}
+
+// Paginator fetches timeline page by page, exposing the cursor
+type Paginator struct {
+	count         int
+	cursor        string
+	done          bool
+	fetchLists    fetchListFunc
+	fetchProfiles fetchProfileFunc
+	fetchTweets   fetchTweetFunc
+	kind          string
+	offset        int
+	page          *Page
+	pageSize      int
+	query         string
+	seen          map[string]bool
+}
+
+// NewTweetPaginator creates paginator of tweets fetched by function like scraper.FetchSearchTweets
+func NewTweetPaginator(query string, fetch func(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error)) *Paginator {
+	p := newPaginator(PageTweets, query)
+	p.fetchTweets = fetch
+	return p
+}
+
+// NewProfilePaginator creates paginator of profiles fetched by function like scraper.FetchSearchProfiles
+func NewProfilePaginator(query string, fetch func(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error)) *Paginator {
+	p := newPaginator(PageProfiles, query)
+	p.fetchProfiles = fetch
+	return p
+}
+
+// NewListPaginator creates paginator of lists fetched by function like scraper.FetchListMembers
+func NewListPaginator(query string, fetch func(query string, maxListsNbr int, cursor string) ([]*List, string, error)) *Paginator {
+	p := newPaginator(PageLists, query)
+	p.fetchLists = fetch
+	return p
+}
+
+func newPaginator(kind, query string) *Paginator {
+	return &Paginator{
+		kind:     kind,
+		pageSize: 50,
+		query:    query,
+		seen:     make(map[string]bool),
+	}
+}
+
+// WithCursor set cursor to start from
+func (p *Paginator) WithCursor(cursor string) *Paginator {
+	p.cursor = cursor
+	return p
+}
+
+// WithPageSize set number of items requested per page
+func (p *Paginator) WithPageSize(size int) *Paginator {
+	if size > 0 {
+		p.pageSize = size
+	}
+	return p
+}
+
+// Cursor returns cursor of the page to continue from
+func (p *Paginator) Cursor() string {
+	if p.consumed() {
+		return p.page.Cursor
+	}
+	return p.cursor
+}
+
+// Count returns number of items consumed so far
+func (p *Paginator) Count() int {
+	return p.count
+}
+
+// HasNext returns false when the timeline is over
+func (p *Paginator) HasNext() bool {
+	return !p.done && !(p.consumed() && p.last())
+}
+
+// Next returns the rest of the current page or fetches the next one,
+// the returned items are consumed. The pagination is over on empty page
+// or when the cursor is missing or repeated.
+func (p *Paginator) Next(ctx context.Context) (*Page, error) {
+	page, err := p.peek(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.consume(page.len())
+	return page, nil
+}
+
+// Unread returns the last n items of the page from Next back to the paginator,
+// so they are returned by the next call and kept by checkpoint
+func (p *Paginator) Unread(n int) {
+	if p.page == nil {
+		return
+	}
+	if n > p.offset {
+		n = p.offset
+	}
+	p.offset -= n
+	p.count -= n
+}
+
+// peek returns unconsumed items of the current page, fetching it when needed
+func (p *Paginator) peek(ctx context.Context) (*Page, error) {
+	if p.consumed() {
+		if p.last() {
+			p.done = true
+		}
+		p.seen[p.cursor] = true
+		p.cursor = p.page.Cursor
+		p.offset = 0
+		p.page = nil
+	}
+	if p.done {
+		return &Page{}, nil
+	}
+	if p.page == nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		page := &Page{}
+		var err error
+		switch p.kind {
+		case PageTweets:
+			page.Tweets, page.Cursor, err = p.fetchTweets(p.query, p.pageSize, p.cursor)
+		case PageProfiles:
+			page.Profiles, page.Cursor, err = p.fetchProfiles(p.query, p.pageSize, p.cursor)
+		case PageLists:
+			page.Lists, page.Cursor, err = p.fetchLists(p.query, p.pageSize, p.cursor)
+		}
+		if err != nil {
+			return nil, err
+		}
+		p.page = page
+	}
+	return p.page.rest(p.offset), nil
+}
+
+func (p *Paginator) consume(n int) {
+	p.offset += n
+	p.count += n
+}
+
+// consumed reports whether all items of the fetched page are consumed
+func (p *Paginator) consumed() bool {
+	return p.page != nil && p.offset >= p.page.len()
+}
+
+// last reports whether the fetched page is the last one
+func (p *Paginator) last() bool {
+	return p.page.len() == 0 || p.page.Cursor == "" || p.page.Cursor == p.cursor || p.seen[p.page.Cursor]
+}
+
+func (page *Page) len() int {
+	return len(page.Tweets) + len(page.Profiles) + len(page.Lists)
+}
+
+// rest returns the page without offset items
+func (page *Page) rest(offset int) *Page {
+	rest := &Page{Cursor: page.Cursor}
+	switch {
+	case offset >= page.len():
+	case page.Tweets != nil:
+		rest.Tweets = page.Tweets[offset:]
+	case page.Profiles != nil:
+		rest.Profiles = page.Profiles[offset:]
+	case page.Lists != nil:
+		rest.Lists = page.Lists[offset:]
+	}
+	return rest
+}
+
+// Checkpoint returns state of the pagination
+func (p *Paginator) Checkpoint() Checkpoint {
+	checkpoint := Checkpoint{
+		Kind:   p.kind,
+		Query:  p.query,
+		Cursor: p.cursor,
+		Offset: p.offset,
+		Count:  p.count,
+		Done:   p.done,
+	}
+	if p.consumed() {
+		checkpoint.Cursor = p.page.Cursor
+		checkpoint.Offset = 0
+		checkpoint.Done = p.done || p.last()
+	}
+	return checkpoint
+}
+
+// Resume continues the pagination from the checkpoint of the same timeline
+func (p *Paginator) Resume(checkpoint Checkpoint) error {
+	if checkpoint.Kind != p.kind || checkpoint.Query != p.query {
+		return fmt.Errorf("checkpoint of %s %q can't resume %s %q", checkpoint.Kind, checkpoint.Query, p.kind, p.query)
+	}
+	p.count = checkpoint.Count
+	p.cursor = checkpoint.Cursor
+	p.offset = checkpoint.Offset
+	p.done = checkpoint.Done
+	p.page = nil
+	return nil
+}
@@ -0,0 +1,158 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+// fetchPages serves pages of two tweets, the last page repeats its cursor
+func fetchPages(pages int) func(string, int, string) ([]*twitterscraper.Tweet, string, error) {
+	return func(query string, maxTweetsNbr int, cursor string) ([]*twitterscraper.Tweet, string, error) {
+		page := 0
+		if cursor != "" {
+			page, _ = strconv.Atoi(cursor)
+		}
+		tweets := []*twitterscraper.Tweet{
+			{ID: strconv.Itoa(page * 2)},
+			{ID: strconv.Itoa(page*2 + 1)},
+		}
+		next := strconv.Itoa(page + 1)
+		if page+1 >= pages {
+			next = cursor
+		}
+		return tweets, next, nil
+	}
+}
+
+func TestPaginator(t *testing.T) {
+	paginator := twitterscraper.NewTweetPaginator("query", fetchPages(3))
+	var ids []string
+	for paginator.HasNext() {
+		page, err := paginator.Next(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.Cursor != paginator.Cursor() {
+			t.Errorf("Expected cursor %q, got %q", page.Cursor, paginator.Cursor())
+		}
+		for _, tweet := range page.Tweets {
+			ids = append(ids, tweet.ID)
+		}
+	}
+	if fmt.Sprint(ids) != "[0 1 2 3 4 5]" {
+		t.Errorf("Expected tweets of 3 pages, got %v", ids)
+	}
+	if paginator.Count() != 6 {
+		t.Errorf("Expected count 6, got %d", paginator.Count())
+	}
+}
+
+func TestPaginatorEmptyPage(t *testing.T) {
+	calls := 0
+	paginator := twitterscraper.NewProfilePaginator("query", func(query string, maxProfilesNbr int, cursor string) ([]*twitterscraper.Profile, string, error) {
+		calls++
+		return nil, "next", nil
+	})
+	for paginator.HasNext() {
+		if _, err := paginator.Next(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected stop after empty page, got %d calls", calls)
+	}
+}
+
+func TestPaginatorCheckpoint(t *testing.T) {
+	paginator := twitterscraper.NewTweetPaginator("query", fetchPages(3))
+	if _, err := paginator.Next(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(paginator.Checkpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"kind":"tweets","query":"query","cursor":"1","count":2}` {
+		t.Errorf("Unexpected checkpoint %s", data)
+	}
+
+	var checkpoint twitterscraper.Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	resumed := twitterscraper.NewTweetPaginator("query", fetchPages(3))
+	if err := resumed.Resume(checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	page, err := resumed.Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Tweets[0].ID != "2" {
+		t.Errorf("Expected resume from the second page, got tweet %s", page.Tweets[0].ID)
+	}
+	if resumed.Count() != 4 {
+		t.Errorf("Expected count 4, got %d", resumed.Count())
+	}
+
+	other := twitterscraper.NewTweetPaginator("other", fetchPages(3))
+	if err := other.Resume(checkpoint); err == nil {
+		t.Error("Expected error on checkpoint of other query")
+	}
+}
+
+func TestPaginatorCheckpointMidPage(t *testing.T) {
+	paginator := twitterscraper.NewTweetPaginator("query", fetchPages(3))
+	page, err := paginator.Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// only the first tweet of the page is processed
+	paginator.Unread(len(page.Tweets) - 1)
+	data, err := json.Marshal(paginator.Checkpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"kind":"tweets","query":"query","offset":1,"count":1}` {
+		t.Errorf("Unexpected checkpoint %s", data)
+	}
+
+	var checkpoint twitterscraper.Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	resumed := twitterscraper.NewTweetPaginator("query", fetchPages(3))
+	if err := resumed.Resume(checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for resumed.HasNext() {
+		page, err := resumed.Next(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tweet := range page.Tweets {
+			ids = append(ids, tweet.ID)
+		}
+	}
+	if fmt.Sprint(ids) != "[1 2 3 4 5]" {
+		t.Errorf("Expected the rest of tweets from the middle of page, got %v", ids)
+	}
+	if resumed.Count() != 6 {
+		t.Errorf("Expected count 6, got %d", resumed.Count())
+	}
+}
+
+func TestPaginatorCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	paginator := twitterscraper.NewTweetPaginator("query", fetchPages(3))
+	if _, err := paginator.Next(ctx); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
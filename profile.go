@@ -20,6 +20,7 @@ type Profile struct {
 	FollowersCount int
 	FollowingCount int
 	FriendsCount   int
+	IsBlueVerified bool
 	IsPrivate      bool
 	IsVerified     bool
 	Joined         *time.Time
@@ -38,8 +39,9 @@ type Profile struct {
 type user struct {
 	Data struct {
 		User struct {
-			RestID string     `json:"rest_id"`
-			Legacy legacyUser `json:"legacy"`
+			RestID         string     `json:"rest_id"`
+			IsBlueVerified bool       `json:"is_blue_verified"`
+			Legacy         legacyUser `json:"legacy"`
 		} `json:"user"`
 	} `json:"data"`
 	Errors []struct {
@@ -82,7 +84,9 @@ func (s *Scraper) GetProfile(username string) (Profile, error) {
 		return Profile{}, fmt.Errorf("either @%s does not exist or is private", username)
 	}
 
-	return parseProfile(jsn.Data.User.Legacy), nil
+	profile := parseProfile(jsn.Data.User.Legacy)
+	profile.IsBlueVerified = profile.IsBlueVerified || jsn.Data.User.IsBlueVerified
+	return profile, nil
 }
 
 // GetUserIDByScreenName from API
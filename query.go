@@ -0,0 +1,384 @@
+package twitterscraper
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SearchFilter type for filter: operator
+type SearchFilter string
+
+const (
+	// FilterMedia - tweets with photos, videos or GIFs
+	FilterMedia SearchFilter = "media"
+	// FilterImages - tweets with photos
+	FilterImages SearchFilter = "images"
+	// FilterVideos - tweets with videos
+	FilterVideos SearchFilter = "videos"
+	// FilterLinks - tweets with links
+	FilterLinks SearchFilter = "links"
+	// FilterReplies - replies
+	FilterReplies SearchFilter = "replies"
+	// FilterRetweets - retweets
+	FilterRetweets SearchFilter = "nativeretweets"
+	// FilterQuote - quote tweets
+	FilterQuote SearchFilter = "quote"
+	// FilterVerified - tweets of verified users
+	FilterVerified SearchFilter = "verified"
+)
+
+const (
+	searchDateFormat     = "2006-01-02"
+	searchDateTimeFormat = "2006-01-02_15:04:05_MST"
+)
+
+// SearchQuery builds search query with standard operators
+type SearchQuery struct {
+	// AllWords must be in the tweet
+	AllWords []string
+	// ExactPhrases must be in the tweet as is
+	ExactPhrases []string
+	// AnyWords - at least one of them must be in the tweet
+	AnyWords []string
+	// Groups are other expressions in parentheses kept as is, e.g. "(from:a OR from:b)",
+	// negated with leading "-", e.g. "-(a OR b)"
+	Groups []string
+	// ExcludeWords must not be in the tweet
+	ExcludeWords []string
+	Hashtags     []string
+	// From, To and Mentions are usernames without @
+	From     string
+	To       string
+	Mentions []string
+	// Since and Until are dates, time of day is kept if it isn't midnight
+	Since          time.Time
+	Until          time.Time
+	SinceID        string
+	MaxID          string
+	MinFaves       int
+	MinRetweets    int
+	MinReplies     int
+	Filters        []SearchFilter
+	ExcludeFilters []SearchFilter
+	Lang           string
+	// Geocode is "latitude,longitude,radius", e.g. "37.7764,-122.4172,10km"
+	Geocode string
+	// Near is a place name, Within is a radius around it, e.g. "15mi"
+	Near           string
+	Within         string
+	URL            string
+	ConversationID string
+}
+
+// String compiles query to the raw search query
+func (q SearchQuery) String() string {
+	var terms []string
+	add := func(format string, values ...interface{}) {
+		terms = append(terms, fmt.Sprintf(format, values...))
+	}
+	for _, word := range q.AllWords {
+		add("%s", quoteTerm(word))
+	}
+	for _, phrase := range q.ExactPhrases {
+		add(`"%s"`, phrase)
+	}
+	if len(q.AnyWords) == 1 {
+		add("%s", quoteTerm(q.AnyWords[0]))
+	} else if len(q.AnyWords) > 1 {
+		words := make([]string, len(q.AnyWords))
+		for i, word := range q.AnyWords {
+			words[i] = quoteTerm(word)
+		}
+		add("(%s)", strings.Join(words, " OR "))
+	}
+	for _, group := range q.Groups {
+		add("%s", group)
+	}
+	for _, word := range q.ExcludeWords {
+		add("-%s", quoteTerm(word))
+	}
+	for _, hashtag := range q.Hashtags {
+		add("#%s", strings.TrimPrefix(hashtag, "#"))
+	}
+	if q.From != "" {
+		add("from:%s", strings.TrimPrefix(q.From, "@"))
+	}
+	if q.To != "" {
+		add("to:%s", strings.TrimPrefix(q.To, "@"))
+	}
+	for _, mention := range q.Mentions {
+		add("@%s", strings.TrimPrefix(mention, "@"))
+	}
+	for _, filter := range q.Filters {
+		add("filter:%s", filter)
+	}
+	for _, filter := range q.ExcludeFilters {
+		add("-filter:%s", filter)
+	}
+	if q.MinFaves > 0 {
+		add("min_faves:%d", q.MinFaves)
+	}
+	if q.MinRetweets > 0 {
+		add("min_retweets:%d", q.MinRetweets)
+	}
+	if q.MinReplies > 0 {
+		add("min_replies:%d", q.MinReplies)
+	}
+	if q.Lang != "" {
+		add("lang:%s", q.Lang)
+	}
+	if !q.Since.IsZero() {
+		add("since:%s", formatSearchTime(q.Since))
+	}
+	if !q.Until.IsZero() {
+		add("until:%s", formatSearchTime(q.Until))
+	}
+	if q.SinceID != "" {
+		add("since_id:%s", q.SinceID)
+	}
+	if q.MaxID != "" {
+		add("max_id:%s", q.MaxID)
+	}
+	if q.Geocode != "" {
+		add("geocode:%s", q.Geocode)
+	}
+	if q.Near != "" {
+		add("near:%s", quoteTerm(q.Near))
+	}
+	if q.Within != "" {
+		add("within:%s", q.Within)
+	}
+	if q.URL != "" {
+		add("url:%s", quoteTerm(q.URL))
+	}
+	if q.ConversationID != "" {
+		add("conversation_id:%s", q.ConversationID)
+	}
+	return strings.Join(terms, " ")
+}
+
+// ParseSearchQuery parses raw search query with standard operators
+func ParseSearchQuery(query string) (SearchQuery, error) {
+	var q SearchQuery
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return q, err
+	}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		// terms joined with OR outside of a group
+		if i+2 < len(tokens) && tokens[i+1] == "OR" {
+			terms := []string{token}
+			for i+2 < len(tokens) && tokens[i+1] == "OR" {
+				terms = append(terms, tokens[i+2])
+				i += 2
+			}
+			q.addAlternatives(terms, "("+strings.Join(terms, " OR ")+")")
+			continue
+		}
+		if strings.HasPrefix(token, "(") {
+			inner, err := tokenizeQuery(token[1 : len(token)-1])
+			if err != nil {
+				return q, err
+			}
+			var terms []string
+			for j, term := range inner {
+				if (j%2 == 1) != (term == "OR") {
+					// not a plain list of alternatives
+					terms = nil
+					break
+				}
+				if term != "OR" {
+					terms = append(terms, term)
+				}
+			}
+			if len(inner)%2 == 0 {
+				terms = nil
+			}
+			q.addAlternatives(terms, token)
+			continue
+		}
+		if isQuoted(token) {
+			q.ExactPhrases = append(q.ExactPhrases, unquoteTerm(token))
+			continue
+		}
+		if strings.HasPrefix(token, "-") && len(token) > 1 {
+			if strings.HasPrefix(token, "-(") {
+				// negated group excludes any of its terms
+				q.Groups = append(q.Groups, token)
+			} else if strings.HasPrefix(token, "-filter:") {
+				q.ExcludeFilters = append(q.ExcludeFilters, SearchFilter(token[len("-filter:"):]))
+			} else {
+				q.ExcludeWords = append(q.ExcludeWords, unquoteTerm(token[1:]))
+			}
+			continue
+		}
+		if strings.HasPrefix(token, "#") && len(token) > 1 {
+			q.Hashtags = append(q.Hashtags, token[1:])
+			continue
+		}
+		if strings.HasPrefix(token, "@") && len(token) > 1 {
+			q.Mentions = append(q.Mentions, token[1:])
+			continue
+		}
+		if err := q.parseOperator(token); err != nil {
+			return q, err
+		}
+	}
+	return q, nil
+}
+
+// addAlternatives sets AnyWords from plain words joined with OR,
+// other expressions (operators, more than one list of alternatives) are kept as the group
+func (q *SearchQuery) addAlternatives(terms []string, group string) {
+	plain := len(terms) > 0 && len(q.AnyWords) == 0
+	for _, term := range terms {
+		if !isPlainTerm(term) {
+			plain = false
+		}
+	}
+	if !plain {
+		q.Groups = append(q.Groups, group)
+		return
+	}
+	for _, term := range terms {
+		q.AnyWords = append(q.AnyWords, unquoteTerm(term))
+	}
+}
+
+// isPlainTerm reports whether token is a word or quoted phrase without operators
+func isPlainTerm(token string) bool {
+	if isQuoted(token) {
+		return true
+	}
+	return token != "" && !strings.ContainsAny(token, `:()"`) && !strings.HasPrefix(token, "-")
+}
+
+// parseOperator sets field of operator token, unknown tokens are kept as words
+func (q *SearchQuery) parseOperator(token string) error {
+	colon := strings.IndexByte(token, ':')
+	if colon <= 0 {
+		q.AllWords = append(q.AllWords, token)
+		return nil
+	}
+	value := unquoteTerm(token[colon+1:])
+	var err error
+	switch token[:colon] {
+	case "from":
+		q.From = value
+	case "to":
+		q.To = value
+	case "since":
+		q.Since, err = parseSearchTime(value)
+	case "until":
+		q.Until, err = parseSearchTime(value)
+	case "since_id":
+		q.SinceID = value
+	case "max_id":
+		q.MaxID = value
+	case "min_faves":
+		q.MinFaves, err = strconv.Atoi(value)
+	case "min_retweets":
+		q.MinRetweets, err = strconv.Atoi(value)
+	case "min_replies":
+		q.MinReplies, err = strconv.Atoi(value)
+	case "filter":
+		q.Filters = append(q.Filters, SearchFilter(value))
+	case "lang":
+		q.Lang = value
+	case "geocode":
+		q.Geocode = value
+	case "near":
+		q.Near = value
+	case "within":
+		q.Within = value
+	case "url":
+		q.URL = value
+	case "conversation_id":
+		q.ConversationID = value
+	default:
+		q.AllWords = append(q.AllWords, token)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s: %v", token[:colon], err)
+	}
+	return nil
+}
+
+// tokenizeQuery splits query by spaces, keeping quoted strings and groups in parentheses
+func tokenizeQuery(query string) ([]string, error) {
+	var tokens []string
+	var token strings.Builder
+	depth := 0
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth < 0 {
+				return nil, errors.New("unbalanced parentheses in search query")
+			}
+		case (r == ' ' || r == '\t' || r == '\n') && depth == 0:
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+			continue
+		}
+		token.WriteRune(r)
+	}
+	if quoted {
+		return nil, errors.New("unclosed quote in search query")
+	}
+	if depth != 0 {
+		return nil, errors.New("unbalanced parentheses in search query")
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+	return tokens, nil
+}
+
+// quoteTerm quotes term with spaces, quoted terms and groups in parentheses are kept as is
+func quoteTerm(term string) string {
+	if strings.ContainsAny(term, " \t") && !strings.Contains(term, `"`) && !strings.HasPrefix(term, "(") {
+		return `"` + term + `"`
+	}
+	return term
+}
+
+// isQuoted reports whether the whole term is a quoted string, like "a b" but not "a"b
+func isQuoted(term string) bool {
+	return len(term) >= 2 && strings.HasPrefix(term, `"`) && strings.HasSuffix(term, `"`) &&
+		!strings.Contains(term[1:len(term)-1], `"`)
+}
+
+func unquoteTerm(term string) string {
+	if isQuoted(term) {
+		return term[1 : len(term)-1]
+	}
+	return term
+}
+
+func formatSearchTime(t time.Time) string {
+	t = t.UTC()
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		return t.Format(searchDateFormat)
+	}
+	return t.Format(searchDateTimeFormat)
+}
+
+func parseSearchTime(value string) (time.Time, error) {
+	if strings.Contains(value, "_") {
+		return time.Parse(searchDateTimeFormat, value)
+	}
+	return time.Parse(searchDateFormat, value)
+}
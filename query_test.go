@@ -0,0 +1,160 @@
+package twitterscraper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestSearchQueryString(t *testing.T) {
+	query := twitterscraper.SearchQuery{
+		AllWords:       []string{"golang", "scraper"},
+		ExactPhrases:   []string{"happy hour"},
+		AnyWords:       []string{"cats", "dogs"},
+		ExcludeWords:   []string{"spam"},
+		Hashtags:       []string{"go"},
+		From:           "Twitter",
+		To:             "@support",
+		Mentions:       []string{"golang"},
+		Since:          time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		Until:          time.Date(2023, 2, 3, 10, 30, 0, 0, time.UTC),
+		MinFaves:       10,
+		Filters:        []twitterscraper.SearchFilter{twitterscraper.FilterMedia},
+		ExcludeFilters: []twitterscraper.SearchFilter{twitterscraper.FilterReplies},
+		Lang:           "en",
+		Near:           "San Francisco",
+		Within:         "15mi",
+	}
+	expected := `golang scraper "happy hour" (cats OR dogs) -spam #go from:Twitter to:support @golang filter:media -filter:replies min_faves:10 lang:en since:2023-01-02 until:2023-02-03_10:30:00_UTC near:"San Francisco" within:15mi`
+	if query.String() != expected {
+		t.Errorf("Expected query:\n%s\ngot:\n%s", expected, query.String())
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	expected := twitterscraper.SearchQuery{
+		AllWords:       []string{"golang", "unknown:op"},
+		ExactPhrases:   []string{"happy hour"},
+		AnyWords:       []string{"cats", "dogs", "birds"},
+		ExcludeWords:   []string{"spam", "bad words"},
+		Hashtags:       []string{"go"},
+		From:           "Twitter",
+		To:             "support",
+		Mentions:       []string{"golang"},
+		Since:          time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		Until:          time.Date(2023, 2, 3, 10, 30, 0, 0, time.UTC),
+		SinceID:        "1328684389388185600",
+		MaxID:          "1328684389388185699",
+		MinFaves:       10,
+		MinRetweets:    5,
+		MinReplies:     2,
+		Filters:        []twitterscraper.SearchFilter{twitterscraper.FilterLinks, twitterscraper.FilterVerified},
+		ExcludeFilters: []twitterscraper.SearchFilter{twitterscraper.FilterReplies},
+		Lang:           "en",
+		Geocode:        "37.7764,-122.4172,10km",
+		URL:            "github.com",
+		ConversationID: "1328684389388185600",
+	}
+	raw := `golang "happy hour" (cats OR dogs OR birds) -spam -"bad words" #go from:Twitter to:support @golang ` +
+		`since:2023-01-02 until:2023-02-03_10:30:00_UTC since_id:1328684389388185600 max_id:1328684389388185699 ` +
+		`min_faves:10 min_retweets:5 min_replies:2 filter:links filter:verified -filter:replies lang:en ` +
+		`geocode:37.7764,-122.4172,10km url:github.com conversation_id:1328684389388185600 unknown:op`
+	query, err := twitterscraper.ParseSearchQuery(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expected, query); diff != "" {
+		t.Error("Resulting query does not match the sample", diff)
+	}
+
+	roundTrip, err := twitterscraper.ParseSearchQuery(query.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(query, roundTrip); diff != "" {
+		t.Error("Query changed after round trip", diff)
+	}
+}
+
+func TestParseSearchQueryErrors(t *testing.T) {
+	for _, raw := range []string{`"unclosed`, `(cats OR dogs`, `min_faves:many`, `since:yesterday`} {
+		if _, err := twitterscraper.ParseSearchQuery(raw); err == nil {
+			t.Errorf("Expected error for query %q", raw)
+		}
+	}
+}
+
+func TestParseSearchQueryGroups(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected twitterscraper.SearchQuery
+		printed  string
+	}{
+		{
+			raw:      `(a b) golang`,
+			expected: twitterscraper.SearchQuery{AllWords: []string{"golang"}, Groups: []string{"(a b)"}},
+			printed:  `golang (a b)`,
+		},
+		{
+			raw:      `(from:x OR from:y) golang`,
+			expected: twitterscraper.SearchQuery{AllWords: []string{"golang"}, Groups: []string{"(from:x OR from:y)"}},
+			printed:  `golang (from:x OR from:y)`,
+		},
+		{
+			raw:      `(a OR b) (c OR d)`,
+			expected: twitterscraper.SearchQuery{AnyWords: []string{"a", "b"}, Groups: []string{"(c OR d)"}},
+			printed:  `(a OR b) (c OR d)`,
+		},
+		{
+			raw:      `a OR b c OR "d e"`,
+			expected: twitterscraper.SearchQuery{AnyWords: []string{"a", "b"}, Groups: []string{`(c OR "d e")`}},
+			printed:  `(a OR b) (c OR "d e")`,
+		},
+		{
+			raw:      `from:x OR from:y`,
+			expected: twitterscraper.SearchQuery{Groups: []string{"(from:x OR from:y)"}},
+			printed:  `(from:x OR from:y)`,
+		},
+		{
+			raw:      `-(a OR b) foo`,
+			expected: twitterscraper.SearchQuery{AllWords: []string{"foo"}, Groups: []string{"-(a OR b)"}},
+			printed:  `foo -(a OR b)`,
+		},
+		{
+			raw:      `"a"b "c d"`,
+			expected: twitterscraper.SearchQuery{AllWords: []string{`"a"b`}, ExactPhrases: []string{"c d"}},
+			printed:  `"a"b "c d"`,
+		},
+		{
+			raw:      `unknown:"a b"`,
+			expected: twitterscraper.SearchQuery{AllWords: []string{`unknown:"a b"`}},
+			printed:  `unknown:"a b"`,
+		},
+	}
+	for _, test := range tests {
+		query, err := twitterscraper.ParseSearchQuery(test.raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(test.expected, query); diff != "" {
+			t.Errorf("Query %q does not match the sample: %s", test.raw, diff)
+		}
+		if query.String() != test.printed {
+			t.Errorf("Expected query %q printed as %q, got %q", test.raw, test.printed, query.String())
+		}
+		roundTrip, err := twitterscraper.ParseSearchQuery(query.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(query, roundTrip); diff != "" {
+			t.Errorf("Query %q changed after round trip: %s", test.raw, diff)
+		}
+	}
+
+	query := twitterscraper.SearchQuery{AllWords: []string{"happy hour"}}
+	if query.String() != `"happy hour"` {
+		t.Errorf("Expected word with space to be quoted, got %s", query.String())
+	}
+}
@@ -16,19 +16,20 @@ import (
 
 // Scraper object
 type Scraper struct {
-	bearerToken    string
-	client         *http.Client
-	delay          int64
-	guestToken     string
-	guestCreatedAt time.Time
-	includeReplies bool
-	isLogged       bool
-	isOpenAccount  bool
-	oAuthToken     string
-	oAuthSecret    string
-	proxy          string
-	searchMode     SearchMode
-	wg             sync.WaitGroup
+	bearerToken     string
+	client          *http.Client
+	delay           int64
+	guestToken      string
+	guestCreatedAt  time.Time
+	includePromoted bool
+	includeReplies  bool
+	isLogged        bool
+	isOpenAccount   bool
+	oAuthToken      string
+	oAuthSecret     string
+	proxy           string
+	searchMode      SearchMode
+	wg              sync.WaitGroup
 }
 
 // SearchMode type
@@ -90,6 +91,12 @@ func (s *Scraper) WithReplies(b bool) *Scraper {
 	return s
 }
 
+// WithPromotedContent enable/disable promoted tweets in home timeline
+func (s *Scraper) WithPromotedContent(b bool) *Scraper {
+	s.includePromoted = b
+	return s
+}
+
 // client timeout
 func (s *Scraper) WithClientTimeout(timeout time.Duration) *Scraper {
 	s.client.Timeout = timeout
@@ -4,21 +4,47 @@ import (
 	"context"
 	"errors"
 	"net/url"
-	"strconv"
 )
 
 const searchURL = "https://twitter.com/i/api/graphql/nK1dw4oV3k4w5TdtcAdSww/SearchTimeline"
 
+// SearchProduct is a tab of search results
+type SearchProduct string
+
+const (
+	// SearchProductTop - popular tweets
+	SearchProductTop SearchProduct = "Top"
+	// SearchProductLatest - live tweets
+	SearchProductLatest SearchProduct = "Latest"
+	// SearchProductMedia - tweets with photos and videos
+	SearchProductMedia SearchProduct = "Media"
+	// SearchProductPeople - profiles
+	SearchProductPeople SearchProduct = "People"
+	// SearchProductLists - lists
+	SearchProductLists SearchProduct = "Lists"
+)
+
+// SearchOptions of the search request
+type SearchOptions struct {
+	// Product is Top by default
+	Product SearchProduct
+	// Count of results per page, up to 50
+	Count int
+	// Cursor to start the search from
+	Cursor string
+	// QuerySource is typed_query by default
+	QuerySource string
+	// TimelineOptions filter tweets of SearchTweetsWithOptions,
+	// the latest search is over on the first tweet older than Since
+	TimelineOptions
+}
+
 type searchTimeline struct {
 	Data struct {
 		SearchByRawQuery struct {
 			SearchTimeline struct {
 				Timeline struct {
-					Instructions []struct {
-						Type    string  `json:"type"`
-						Entries []entry `json:"entries"`
-						Entry   entry   `json:"entry,omitempty"`
-					} `json:"instructions"`
+					Instructions []instruction `json:"instructions"`
 				} `json:"timeline"`
 			} `json:"search_timeline"`
 		} `json:"search_by_raw_query"`
@@ -26,29 +52,8 @@ type searchTimeline struct {
 }
 
 func (timeline *searchTimeline) parseTweets() ([]*Tweet, string) {
-	tweets := make([]*Tweet, 0)
-	cursor := ""
-	for _, instruction := range timeline.Data.SearchByRawQuery.SearchTimeline.Timeline.Instructions {
-		if instruction.Type == "TimelineAddEntries" || instruction.Type == "TimelineReplaceEntry" {
-			if instruction.Entry.Content.CursorType == "Bottom" {
-				cursor = instruction.Entry.Content.Value
-				continue
-			}
-			for _, entry := range instruction.Entries {
-				if entry.Content.ItemContent.TweetDisplayType == "Tweet" {
-					if tweet := parseLegacyTweet(&entry.Content.ItemContent.TweetResults.Result.Core.UserResults.Result.Legacy, &entry.Content.ItemContent.TweetResults.Result.Legacy); tweet != nil {
-						if tweet.Views == 0 && entry.Content.ItemContent.TweetResults.Result.Views.Count != "" {
-							tweet.Views, _ = strconv.Atoi(entry.Content.ItemContent.TweetResults.Result.Views.Count)
-						}
-						tweets = append(tweets, tweet)
-					}
-				} else if entry.Content.CursorType == "Bottom" {
-					cursor = entry.Content.Value
-				}
-			}
-		}
-	}
-	return tweets, cursor
+	// media results come as a grid module
+	return parseTimelineTweets(timeline.Data.SearchByRawQuery.SearchTimeline.Timeline.Instructions, false)
 }
 
 func (timeline *searchTimeline) parseUsers() ([]*Profile, string) {
@@ -62,10 +67,7 @@ func (timeline *searchTimeline) parseUsers() ([]*Profile, string) {
 			}
 			for _, entry := range instruction.Entries {
 				if entry.Content.ItemContent.UserDisplayType == "User" {
-					if profile := parseProfile(entry.Content.ItemContent.UserResults.Result.Legacy); profile.Name != "" {
-						if profile.UserID == "" {
-							profile.UserID = entry.Content.ItemContent.UserResults.Result.RestID
-						}
+					if profile := entry.Content.ItemContent.UserResults.Result.parse(); profile.Name != "" {
 						profiles = append(profiles, &profile)
 					}
 				} else if entry.Content.CursorType == "Bottom" {
@@ -77,9 +79,23 @@ func (timeline *searchTimeline) parseUsers() ([]*Profile, string) {
 	return profiles, cursor
 }
 
+func (timeline *searchTimeline) parseLists() ([]*List, string) {
+	return parseTimelineLists(timeline.Data.SearchByRawQuery.SearchTimeline.Timeline.Instructions)
+}
+
 // SearchTweets returns channel with tweets for a given search query
 func (s *Scraper) SearchTweets(ctx context.Context, query string, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, query, maxTweetsNbr, s.FetchSearchTweets)
+	return getTweetTimeline(ctx, query, maxTweetsNbr, timelineFilter{}, s.FetchSearchTweets)
+}
+
+// SearchTweetsWithOptions returns channel with tweets for a given search query and options
+func (s *Scraper) SearchTweetsWithOptions(ctx context.Context, query string, maxTweetsNbr int, opts SearchOptions) <-chan *TweetResult {
+	return getTweetTimeline(ctx, query, maxTweetsNbr, opts.timelineFilter(opts.Product == SearchProductLatest), func(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		if cursor != "" {
+			opts.Cursor = cursor
+		}
+		return s.FetchSearchTweetsWithOptions(query, maxTweetsNbr, opts)
+	})
 }
 
 // SearchProfiles returns channel with profiles for a given search query
@@ -87,15 +103,40 @@ func (s *Scraper) SearchProfiles(ctx context.Context, query string, maxProfilesN
 	return getUserTimeline(ctx, query, maxProfilesNbr, s.FetchSearchProfiles)
 }
 
+// SearchProfilesWithOptions returns channel with profiles for a given search query and options,
+// the product is always People
+func (s *Scraper) SearchProfilesWithOptions(ctx context.Context, query string, maxProfilesNbr int, opts SearchOptions) <-chan *ProfileResult {
+	return getUserTimeline(ctx, query, maxProfilesNbr, func(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+		if cursor != "" {
+			opts.Cursor = cursor
+		}
+		return s.FetchSearchProfilesWithOptions(query, maxProfilesNbr, opts)
+	})
+}
+
+// SearchLists returns channel with lists for a given search query
+func (s *Scraper) SearchLists(ctx context.Context, query string, maxListsNbr int) <-chan *ListResult {
+	return getListTimeline(ctx, query, maxListsNbr, s.FetchSearchLists)
+}
+
 // getSearchTimeline gets results for a given search query, via the Twitter frontend API
-func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*searchTimeline, error) {
+func (s *Scraper) getSearchTimeline(query string, maxNbr int, opts SearchOptions) (*searchTimeline, error) {
 	if !s.isLogged {
 		return nil, errors.New("scraper is not logged in for search")
 	}
 
+	if opts.Count > 0 {
+		maxNbr = opts.Count
+	}
 	if maxNbr > 50 {
 		maxNbr = 50
 	}
+	if opts.Product == "" {
+		opts.Product = SearchProductTop
+	}
+	if opts.QuerySource == "" {
+		opts.QuerySource = "typed_query"
+	}
 
 	req, err := s.newRequest("GET", searchURL)
 	if err != nil {
@@ -105,8 +146,8 @@ func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*s
 	variables := map[string]interface{}{
 		"rawQuery":    query,
 		"count":       maxNbr,
-		"querySource": "typed_query",
-		"product":     "Top",
+		"querySource": opts.QuerySource,
+		"product":     opts.Product,
 	}
 
 	features := map[string]interface{}{
@@ -136,18 +177,8 @@ func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*s
 		"withArticleRichContentState": false,
 	}
 
-	if cursor != "" {
-		variables["cursor"] = cursor
-	}
-	switch s.searchMode {
-	case SearchLatest:
-		variables["product"] = "Latest"
-	case SearchPhotos:
-		variables["product"] = "Photos"
-	case SearchVideos:
-		variables["product"] = "Videos"
-	case SearchUsers:
-		variables["product"] = "People"
+	if opts.Cursor != "" {
+		variables["cursor"] = opts.Cursor
 	}
 
 	q := url.Values{}
@@ -164,9 +195,49 @@ func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*s
 	return &timeline, nil
 }
 
+// searchOptions returns options for the search mode of the scraper
+func (s *Scraper) searchOptions(cursor string) SearchOptions {
+	opts := SearchOptions{Cursor: cursor, Product: SearchProductTop}
+	switch s.searchMode {
+	case SearchLatest:
+		opts.Product = SearchProductLatest
+	case SearchPhotos, SearchVideos:
+		opts.Product = SearchProductMedia
+	case SearchUsers:
+		opts.Product = SearchProductPeople
+	}
+	return opts
+}
+
 // FetchSearchTweets gets tweets for a given search query, via the Twitter frontend API
 func (s *Scraper) FetchSearchTweets(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-	timeline, err := s.getSearchTimeline(query, maxTweetsNbr, cursor)
+	opts := s.searchOptions(cursor)
+	if s.searchMode != SearchPhotos && s.searchMode != SearchVideos {
+		return s.FetchSearchTweetsWithOptions(query, maxTweetsNbr, opts)
+	}
+	// Photos and Videos tabs are merged into Media, so tweets are filtered
+	for {
+		tweets, nextCursor, err := s.FetchSearchTweetsWithOptions(query, maxTweetsNbr, opts)
+		if err != nil || len(tweets) == 0 {
+			return tweets, nextCursor, err
+		}
+		var filtered []*Tweet
+		for _, tweet := range tweets {
+			if (s.searchMode == SearchPhotos && len(tweet.Photos) > 0) ||
+				(s.searchMode == SearchVideos && len(tweet.Videos) > 0) {
+				filtered = append(filtered, tweet)
+			}
+		}
+		if len(filtered) > 0 || nextCursor == "" || nextCursor == opts.Cursor {
+			return filtered, nextCursor, nil
+		}
+		opts.Cursor = nextCursor
+	}
+}
+
+// FetchSearchTweetsWithOptions gets tweets for a given search query and options
+func (s *Scraper) FetchSearchTweetsWithOptions(query string, maxTweetsNbr int, opts SearchOptions) ([]*Tweet, string, error) {
+	timeline, err := s.getSearchTimeline(query, maxTweetsNbr, opts)
 	if err != nil {
 		return nil, "", err
 	}
@@ -176,10 +247,27 @@ func (s *Scraper) FetchSearchTweets(query string, maxTweetsNbr int, cursor strin
 
 // FetchSearchProfiles gets users for a given search query, via the Twitter frontend API
 func (s *Scraper) FetchSearchProfiles(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
-	timeline, err := s.getSearchTimeline(query, maxProfilesNbr, cursor)
+	return s.FetchSearchProfilesWithOptions(query, maxProfilesNbr, SearchOptions{Cursor: cursor})
+}
+
+// FetchSearchProfilesWithOptions gets users for a given search query and options,
+// the product is always People
+func (s *Scraper) FetchSearchProfilesWithOptions(query string, maxProfilesNbr int, opts SearchOptions) ([]*Profile, string, error) {
+	opts.Product = SearchProductPeople
+	timeline, err := s.getSearchTimeline(query, maxProfilesNbr, opts)
 	if err != nil {
 		return nil, "", err
 	}
 	users, nextCursor := timeline.parseUsers()
 	return users, nextCursor, nil
 }
+
+// FetchSearchLists gets lists for a given search query, via the Twitter frontend API
+func (s *Scraper) FetchSearchLists(query string, maxListsNbr int, cursor string) ([]*List, string, error) {
+	timeline, err := s.getSearchTimeline(query, maxListsNbr, SearchOptions{Product: SearchProductLists, Cursor: cursor})
+	if err != nil {
+		return nil, "", err
+	}
+	lists, nextCursor := timeline.parseLists()
+	return lists, nextCursor, nil
+}
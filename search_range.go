@@ -0,0 +1,215 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SearchRangeOptions of time-sliced search
+type SearchRangeOptions struct {
+	// Window is initial duration of time slices, 24 hours by default
+	Window time.Duration
+	// MinWindow is the shortest slice a dense window can be split into, 10 minutes by default
+	MinWindow time.Duration
+	// Dense is number of tweets after which the rest of window is split in halves,
+	// as the search cuts off deep pagination. 500 by default.
+	Dense int
+	// Concurrency is number of windows fetched in parallel ahead of the emitted ones, 2 by default
+	Concurrency int
+	// MaxTweets limits number of tweets, 0 for no limit
+	MaxTweets int
+	// Filter keeps tweets it returns true for, nil keeps all tweets
+	Filter TweetFilter
+}
+
+type searchWindow struct {
+	since, until time.Time
+	started      bool
+	done         bool
+	tweets       []*Tweet
+	err          error
+}
+
+type searchRange struct {
+	ctx     context.Context
+	fetch   fetchTweetFunc
+	opts    SearchRangeOptions
+	query   string
+	results chan *searchWindow
+	wg      sync.WaitGroup
+}
+
+// SearchTweetsRange returns channel with all tweets for a given search query
+// created between since and until, the newest first.
+// The range is searched by time windows, so results are not cut off by pagination limits.
+func (s *Scraper) SearchTweetsRange(ctx context.Context, query string, since, until time.Time, opts SearchRangeOptions) <-chan *TweetResult {
+	return searchTweetsRange(ctx, query, since, until, opts, func(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		timeline, err := s.getSearchTimeline(query, maxTweetsNbr, SearchOptions{Product: SearchProductLatest, Cursor: cursor})
+		if err != nil {
+			return nil, "", err
+		}
+		tweets, nextCursor := timeline.parseTweets()
+		return tweets, nextCursor, nil
+	})
+}
+
+func searchTweetsRange(ctx context.Context, query string, since, until time.Time, opts SearchRangeOptions, fetch fetchTweetFunc) <-chan *TweetResult {
+	if opts.Window <= 0 {
+		opts.Window = 24 * time.Hour
+	}
+	if opts.MinWindow <= 0 {
+		opts.MinWindow = 10 * time.Minute
+	}
+	if opts.Dense <= 0 {
+		opts.Dense = 500
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 2
+	}
+
+	channel := make(chan *TweetResult)
+	ctx, cancel := context.WithCancel(ctx)
+	sr := &searchRange{
+		ctx:     ctx,
+		fetch:   fetch,
+		opts:    opts,
+		query:   query,
+		results: make(chan *searchWindow),
+	}
+
+	go func() {
+		defer close(channel)
+		defer sr.wg.Wait()
+		defer cancel()
+		seen := make(map[string]bool)
+		count := 0
+		running := 0
+		// windows waiting for emit, the newest first like the search results,
+		// split windows are followed by their parts
+		var queue []*searchWindow
+		end := until
+		for {
+			// windows are fetched in emit order, no more than Concurrency of them ahead
+			for len(queue) < opts.Concurrency && end.After(since) {
+				start := end.Add(-opts.Window)
+				if start.Before(since) {
+					start = since
+				}
+				queue = append(queue, &searchWindow{since: start, until: end})
+				end = start
+			}
+			for i := 0; i < len(queue) && i < opts.Concurrency && running < opts.Concurrency; i++ {
+				if !queue[i].started {
+					sr.start(queue[i])
+					running++
+				}
+			}
+			if len(queue) == 0 {
+				return
+			}
+
+			w := queue[0]
+			if !w.done {
+				select {
+				case result := <-sr.results:
+					running--
+					result.done = true
+					if result.err == nil && len(result.tweets) >= opts.Dense {
+						queue = sr.split(queue, result)
+					}
+				case <-ctx.Done():
+					channel <- &TweetResult{Error: ctx.Err()}
+					return
+				}
+				continue
+			}
+			if w.err != nil {
+				channel <- &TweetResult{Error: w.err}
+				return
+			}
+			for _, tweet := range w.tweets {
+				if seen[tweet.ID] {
+					continue
+				}
+				seen[tweet.ID] = true
+				if opts.Filter != nil && !opts.Filter(tweet) {
+					continue
+				}
+				select {
+				case channel <- &TweetResult{Tweet: *tweet}:
+				case <-ctx.Done():
+					return
+				}
+				count++
+				if opts.MaxTweets > 0 && count >= opts.MaxTweets {
+					return
+				}
+			}
+			queue = queue[1:]
+		}
+	}()
+	return channel
+}
+
+// start fetches the window in background
+func (sr *searchRange) start(w *searchWindow) {
+	w.started = true
+	sr.wg.Add(1)
+	go func() {
+		defer sr.wg.Done()
+		w.err = sr.fetchWindow(w)
+		select {
+		case sr.results <- w:
+		case <-sr.ctx.Done():
+		}
+	}()
+}
+
+func (sr *searchRange) fetchWindow(w *searchWindow) error {
+	// since_id is exclusive and max_id is inclusive
+	query := fmt.Sprintf("%s since_id:%s max_id:%s", sr.query,
+		MaxTweetID(w.since.Add(-time.Millisecond)), MaxTweetID(w.until.Add(-time.Millisecond)))
+	var cursor string
+	for len(w.tweets) < sr.opts.Dense {
+		if err := sr.ctx.Err(); err != nil {
+			return err
+		}
+		tweets, next, err := sr.fetch(query, 50, cursor)
+		if err != nil {
+			return err
+		}
+		w.tweets = append(w.tweets, tweets...)
+		if len(tweets) == 0 || next == "" || next == cursor {
+			break
+		}
+		cursor = next
+	}
+	return nil
+}
+
+// split inserts parts of the rest of dense window (older than its last tweet)
+// into the queue after the window
+func (sr *searchRange) split(queue []*searchWindow, w *searchWindow) []*searchWindow {
+	last, err := TweetIDTime(w.tweets[len(w.tweets)-1].ID)
+	if err != nil {
+		return queue
+	}
+	// tweets of the same millisecond are deduplicated
+	until := last.Add(time.Millisecond)
+	if !until.Before(w.until) || !until.After(w.since) {
+		return queue
+	}
+	parts := []*searchWindow{{since: w.since, until: until}}
+	if until.Sub(w.since) >= 2*sr.opts.MinWindow {
+		middle := w.since.Add(until.Sub(w.since) / 2)
+		parts = []*searchWindow{{since: middle, until: until}, {since: w.since, until: middle}}
+	}
+	for i := range queue {
+		if queue[i] == w {
+			return append(queue[:i+1], append(parts, queue[i+1:]...)...)
+		}
+	}
+	return queue
+}
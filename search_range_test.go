@@ -0,0 +1,119 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// rangeFetcher serves tweets by since_id and max_id of the query with pages of 3 tweets
+type rangeFetcher struct {
+	mu      sync.Mutex
+	ids     []uint64
+	running int
+	maxRun  int
+	windows []string
+}
+
+func (f *rangeFetcher) fetch(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	var sinceID, maxID uint64
+	if _, err := fmt.Sscanf(query, "query since_id:%d max_id:%d", &sinceID, &maxID); err != nil {
+		return nil, "", err
+	}
+	f.mu.Lock()
+	f.running++
+	if f.running > f.maxRun {
+		f.maxRun = f.running
+	}
+	if cursor == "" {
+		f.windows = append(f.windows, query)
+	}
+	f.mu.Unlock()
+	time.Sleep(time.Millisecond)
+	defer func() {
+		f.mu.Lock()
+		f.running--
+		f.mu.Unlock()
+	}()
+
+	offset, _ := strconv.Atoi(cursor)
+	var tweets []*Tweet
+	skipped := 0
+	for _, id := range f.ids {
+		if id <= sinceID || id > maxID {
+			continue
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		tweets = append(tweets, &Tweet{ID: strconv.FormatUint(id, 10)})
+		if len(tweets) == 3 {
+			break
+		}
+	}
+	return tweets, strconv.Itoa(offset + len(tweets)), nil
+}
+
+func TestSearchTweetsRangeWindows(t *testing.T) {
+	until := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	since := until.Add(-4 * time.Hour)
+	fetcher := &rangeFetcher{}
+	// ten tweets in each hour, the newest first
+	for i := 0; i < 40; i++ {
+		id, _ := strconv.ParseUint(MinTweetID(until.Add(-time.Duration(i+1)*6*time.Minute)), 10, 64)
+		fetcher.ids = append(fetcher.ids, id)
+	}
+	opts := SearchRangeOptions{Window: time.Hour, MinWindow: time.Minute, Dense: 6, Concurrency: 2}
+	var ids []uint64
+	for tweet := range searchTweetsRange(context.Background(), "query", since, until, opts, fetcher.fetch) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		id, _ := strconv.ParseUint(tweet.ID, 10, 64)
+		ids = append(ids, id)
+	}
+	if len(ids) != len(fetcher.ids) {
+		t.Fatalf("Expected %d tweets, got %d", len(fetcher.ids), len(ids))
+	}
+	for i, id := range ids {
+		if id != fetcher.ids[i] {
+			t.Fatalf("Tweet %d out of order: expected %d, got %d", i, fetcher.ids[i], id)
+		}
+	}
+	// dense hours are split, so there are more windows than hours
+	if len(fetcher.windows) <= 4 {
+		t.Errorf("Expected dense windows to be split, got %d windows", len(fetcher.windows))
+	}
+	if fetcher.maxRun > opts.Concurrency {
+		t.Errorf("Expected no more than %d parallel fetches, got %d", opts.Concurrency, fetcher.maxRun)
+	}
+}
+
+func TestSearchTweetsRangeLazy(t *testing.T) {
+	until := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	since := until.Add(-24 * time.Hour)
+	fetcher := &rangeFetcher{}
+	for i := 0; i < 24; i++ {
+		id, _ := strconv.ParseUint(MinTweetID(until.Add(-time.Duration(i)*time.Hour-time.Minute)), 10, 64)
+		fetcher.ids = append(fetcher.ids, id)
+	}
+	opts := SearchRangeOptions{Window: time.Hour, Concurrency: 2, MaxTweets: 1}
+	count := 0
+	for tweet := range searchTweetsRange(context.Background(), "query", since, until, opts, fetcher.fetch) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		count++
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 tweet, got %d", count)
+	}
+	// windows are fetched only ahead of the emitted one
+	if len(fetcher.windows) > opts.Concurrency {
+		t.Errorf("Expected no more than %d windows fetched, got %d", opts.Concurrency, len(fetcher.windows))
+	}
+}
@@ -3,6 +3,7 @@ package twitterscraper_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	twitterscraper "github.com/n0madic/twitter-scraper"
 )
@@ -95,3 +96,76 @@ func TestGetSearchTweets(t *testing.T) {
 		t.Errorf("Expected tweets count=%v, got: %v", maxTweetsNbr, count)
 	}
 }
+
+func TestSearchTweetsRange(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	until := time.Now().Add(-time.Hour).Truncate(time.Hour)
+	since := until.Add(-3 * time.Hour)
+	opts := twitterscraper.SearchRangeOptions{Window: time.Hour, MaxTweets: 300}
+	count := 0
+	dupcheck := make(map[string]bool)
+	var previous time.Time
+	for tweet := range testScraper.SearchTweetsRange(context.Background(), "twitter", since, until, opts) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		count++
+		if dupcheck[tweet.ID] {
+			t.Errorf("Detect duplicated tweet ID: %s", tweet.ID)
+		}
+		dupcheck[tweet.ID] = true
+		if tweet.TimeParsed.Before(since) || !tweet.TimeParsed.Before(until) {
+			t.Errorf("Tweet %s time %v is out of range", tweet.ID, tweet.TimeParsed)
+		}
+		if !previous.IsZero() && tweet.TimeParsed.After(previous) {
+			t.Errorf("Tweet %s is newer than the previous one", tweet.ID)
+		}
+		previous = tweet.TimeParsed
+	}
+	if count != opts.MaxTweets {
+		t.Errorf("Expected tweets count=%v, got: %v", opts.MaxTweets, count)
+	}
+}
+
+func TestSearchTweetsWithOptions(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	for _, product := range []twitterscraper.SearchProduct{twitterscraper.SearchProductTop, twitterscraper.SearchProductLatest, twitterscraper.SearchProductMedia} {
+		count := 0
+		opts := twitterscraper.SearchOptions{Product: product, Count: 20}
+		for tweet := range testScraper.SearchTweetsWithOptions(context.Background(), "twitter", 40, opts) {
+			if tweet.Error != nil {
+				t.Fatal(tweet.Error)
+			}
+			count++
+			if product == twitterscraper.SearchProductMedia && len(tweet.Photos) == 0 && len(tweet.Videos) == 0 && len(tweet.GIFs) == 0 {
+				t.Errorf("Expected media in tweet %s", tweet.ID)
+			}
+		}
+		if count != 40 {
+			t.Errorf("Expected tweets count=40 for product %s, got: %v", product, count)
+		}
+	}
+}
+
+func TestSearchLists(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	count := 0
+	for list := range testScraper.SearchLists(context.Background(), "news", 20) {
+		if list.Error != nil {
+			t.Fatal(list.Error)
+		}
+		count++
+		if list.ID == "" || list.Name == "" {
+			t.Errorf("Expected list ID and name, got %q %q", list.ID, list.Name)
+		}
+	}
+	if count == 0 {
+		t.Error("Expected lists in search results")
+	}
+}
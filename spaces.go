@@ -0,0 +1,174 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const audioSpaceURL = "https://twitter.com/i/api/graphql/gpc0LEdR6URXZ7HOo42_bQ/AudioSpaceById"
+
+// SpaceState type
+type SpaceState string
+
+const (
+	// SpaceScheduled - space is scheduled and not started yet
+	SpaceScheduled SpaceState = "scheduled"
+	// SpaceLive - space is running now
+	SpaceLive SpaceState = "live"
+	// SpaceEnded - space is over
+	SpaceEnded SpaceState = "ended"
+	// SpaceCanceled - scheduled space was canceled
+	SpaceCanceled SpaceState = "canceled"
+	// SpaceUnknown - state isn't known to the scraper
+	SpaceUnknown SpaceState = "unknown"
+)
+
+// Space (audio room) type.
+type Space struct {
+	EndedAt            time.Time
+	Host               Profile
+	ID                 string
+	IsReplayAvailable  bool
+	Listeners          []Profile
+	ParticipantCount   int
+	ScheduledStart     time.Time
+	Speakers           []Profile
+	StartedAt          time.Time
+	State              SpaceState
+	Title              string
+	TotalLiveListeners int
+	TotalReplayWatched int
+	URL                string
+}
+
+type spaceParticipant struct {
+	AvatarURL         string `json:"avatar_url"`
+	DisplayName       string `json:"display_name"`
+	IsVerified        bool   `json:"is_verified"`
+	TwitterScreenName string `json:"twitter_screen_name"`
+	UserResults       struct {
+		RestID string     `json:"rest_id"`
+		Result userResult `json:"result"`
+	} `json:"user_results"`
+}
+
+func (participant *spaceParticipant) parse() Profile {
+	if participant.UserResults.Result.Legacy.ScreenName != "" {
+		profile := participant.UserResults.Result.parse()
+		if profile.UserID == "" {
+			profile.UserID = participant.UserResults.RestID
+		}
+		return profile
+	}
+	return Profile{
+		Avatar:     participant.AvatarURL,
+		IsVerified: participant.IsVerified,
+		Name:       participant.DisplayName,
+		URL:        "https://twitter.com/" + participant.TwitterScreenName,
+		UserID:     participant.UserResults.RestID,
+		Username:   participant.TwitterScreenName,
+	}
+}
+
+type audioSpace struct {
+	Data struct {
+		AudioSpace struct {
+			Metadata struct {
+				RestID         string `json:"rest_id"`
+				State          string `json:"state"`
+				Title          string `json:"title"`
+				CreatorResults struct {
+					Result userResult `json:"result"`
+				} `json:"creator_results"`
+				EndedAt                   msecTimestamp `json:"ended_at"`
+				IsSpaceAvailableForReplay bool          `json:"is_space_available_for_replay"`
+				ScheduledStart            msecTimestamp `json:"scheduled_start"`
+				StartedAt                 msecTimestamp `json:"started_at"`
+				TotalLiveListeners        int           `json:"total_live_listeners"`
+				TotalReplayWatched        int           `json:"total_replay_watched"`
+			} `json:"metadata"`
+			Participants struct {
+				Total     int                `json:"total"`
+				Admins    []spaceParticipant `json:"admins"`
+				Speakers  []spaceParticipant `json:"speakers"`
+				Listeners []spaceParticipant `json:"listeners"`
+			} `json:"participants"`
+		} `json:"audioSpace"`
+	} `json:"data"`
+}
+
+// GetSpace return space (audio room) metadata by ID.
+func (s *Scraper) GetSpace(ctx context.Context, spaceID string) (*Space, error) {
+	req, err := s.newRequest("GET", audioSpaceURL)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	variables := map[string]interface{}{
+		"id":                spaceID,
+		"isMetatagsQuery":   false,
+		"withReplays":       true,
+		"withListeners":     true,
+		"withSuperFollows":  true,
+		"withDownvotePerms": false,
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(timelineFeatures()))
+	req.URL.RawQuery = query.Encode()
+
+	var jsn audioSpace
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := jsn.Data.AudioSpace.Metadata
+	if metadata.RestID == "" {
+		return nil, fmt.Errorf("space with ID %s not found", spaceID)
+	}
+
+	space := &Space{
+		EndedAt:            metadata.EndedAt.Time(),
+		Host:               metadata.CreatorResults.Result.parse(),
+		ID:                 metadata.RestID,
+		IsReplayAvailable:  metadata.IsSpaceAvailableForReplay,
+		ParticipantCount:   jsn.Data.AudioSpace.Participants.Total,
+		ScheduledStart:     metadata.ScheduledStart.Time(),
+		StartedAt:          metadata.StartedAt.Time(),
+		Title:              metadata.Title,
+		TotalLiveListeners: metadata.TotalLiveListeners,
+		TotalReplayWatched: metadata.TotalReplayWatched,
+		URL:                "https://twitter.com/i/spaces/" + metadata.RestID,
+	}
+
+	switch metadata.State {
+	case "NotStarted", "PrePublished":
+		space.State = SpaceScheduled
+	case "Running":
+		space.State = SpaceLive
+	case "Ended", "TimedOut":
+		space.State = SpaceEnded
+	case "Canceled":
+		space.State = SpaceCanceled
+	default:
+		space.State = SpaceUnknown
+	}
+
+	// admins are hosts and co-hosts, they are speaking in the space too
+	for _, participant := range jsn.Data.AudioSpace.Participants.Admins {
+		space.Speakers = append(space.Speakers, participant.parse())
+	}
+	for _, participant := range jsn.Data.AudioSpace.Participants.Speakers {
+		space.Speakers = append(space.Speakers, participant.parse())
+	}
+	for _, participant := range jsn.Data.AudioSpace.Participants.Listeners {
+		space.Listeners = append(space.Listeners, participant.parse())
+	}
+
+	return space, nil
+}
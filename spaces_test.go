@@ -0,0 +1,49 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestGetSpace(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var spaceID string
+	for tweet := range testScraper.SearchTweets(ctx, "url:i/spaces", 20) {
+		// the channel is drained after cancel, so the timeline goroutine ends
+		if spaceID != "" {
+			continue
+		}
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if tweet.SpaceID != "" {
+			spaceID = tweet.SpaceID
+			cancel()
+		}
+	}
+	if spaceID == "" {
+		t.Skip("No tweets with spaces found")
+	}
+
+	space, err := testScraper.GetSpace(context.Background(), spaceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if space.ID != spaceID {
+		t.Errorf("Expected space ID %s, got %s", spaceID, space.ID)
+	}
+	if space.Host.Username == "" {
+		t.Error("Expected space Host is empty")
+	}
+	switch space.State {
+	case twitterscraper.SpaceScheduled, twitterscraper.SpaceLive, twitterscraper.SpaceEnded, twitterscraper.SpaceCanceled:
+	default:
+		t.Errorf("Unexpected space State %q", space.State)
+	}
+}
@@ -0,0 +1 @@
+270-0;
\ No newline at end of file
@@ -0,0 +1 @@
+270-1;
\ No newline at end of file
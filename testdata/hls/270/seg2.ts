@@ -0,0 +1 @@
+270-2;
\ No newline at end of file
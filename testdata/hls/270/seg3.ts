@@ -0,0 +1 @@
+270-3;
\ No newline at end of file
@@ -0,0 +1 @@
+360-0;
\ No newline at end of file
@@ -0,0 +1 @@
+360-1;
\ No newline at end of file
@@ -0,0 +1 @@
+360-2;
\ No newline at end of file
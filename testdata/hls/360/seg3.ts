@@ -0,0 +1 @@
+360-3;
\ No newline at end of file
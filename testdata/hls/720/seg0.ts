@@ -0,0 +1 @@
+720-0;
\ No newline at end of file
@@ -0,0 +1 @@
+720-2;
\ No newline at end of file
@@ -0,0 +1 @@
+720-3;
\ No newline at end of file
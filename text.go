@@ -0,0 +1,233 @@
+package twitterscraper
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Twitter escapes only these characters in the tweet text
+var (
+	textUnescaper = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">")
+	htmlEscaper   = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+type entitySpan struct {
+	start, end int // rune offsets in unescaped text
+	kind       string
+	index      int // index in the entities list of the kind
+}
+
+// parseEntities fills Entities, HTML and DisplayText of the tweet.
+// The API returns entity indices in code points of the unescaped text,
+// the tweet entities get them in UTF-16 code units.
+func parseEntities(tw *Tweet, tweet *legacyTweet) {
+	text := []rune(textUnescaper.Replace(tweet.FullText))
+	offsets := utf16Offsets(text)
+
+	var spans []entitySpan
+	used := make([]bool, len(text))
+	add := func(kind string, index int, indices [2]int, token string) bool {
+		start, end, ok := locateEntity(text, used, indices, token)
+		if !ok {
+			return false
+		}
+		for i := start; i < end; i++ {
+			used[i] = true
+		}
+		spans = append(spans, entitySpan{start: start, end: end, kind: kind, index: index})
+		return true
+	}
+
+	for _, hashtag := range tweet.Entities.Hashtags {
+		entity := HashtagEntity{Text: hashtag.Text}
+		if add("hashtag", len(tw.Entities.Hashtags), hashtag.Indices, "#"+hashtag.Text) {
+			s := spans[len(spans)-1]
+			entity.Indices = [2]int{offsets[s.start], offsets[s.end]}
+		}
+		tw.Entities.Hashtags = append(tw.Entities.Hashtags, entity)
+	}
+	for _, symbol := range tweet.Entities.Symbols {
+		entity := HashtagEntity{Text: symbol.Text}
+		if add("cashtag", len(tw.Entities.Cashtags), symbol.Indices, "$"+symbol.Text) {
+			s := spans[len(spans)-1]
+			entity.Indices = [2]int{offsets[s.start], offsets[s.end]}
+		}
+		tw.Entities.Cashtags = append(tw.Entities.Cashtags, entity)
+	}
+	for _, mention := range tweet.Entities.UserMentions {
+		entity := MentionEntity{
+			ID:       mention.IDStr,
+			Name:     mention.Name,
+			Username: mention.ScreenName,
+		}
+		if add("mention", len(tw.Entities.Mentions), mention.Indices, "@"+mention.ScreenName) {
+			s := spans[len(spans)-1]
+			entity.Indices = [2]int{offsets[s.start], offsets[s.end]}
+		}
+		tw.Entities.Mentions = append(tw.Entities.Mentions, entity)
+	}
+	for _, u := range tweet.Entities.URLs {
+		entity := URLEntity{
+			DisplayURL:  u.DisplayURL,
+			ExpandedURL: u.ExpandedURL,
+			URL:         u.URL,
+		}
+		if add("url", len(tw.Entities.URLs), u.Indices, u.URL) {
+			s := spans[len(spans)-1]
+			entity.Indices = [2]int{offsets[s.start], offsets[s.end]}
+		}
+		tw.Entities.URLs = append(tw.Entities.URLs, entity)
+	}
+	// all media of the tweet share the same link, it is rendered once
+	mediaSpan := false
+	for _, media := range tweet.ExtendedEntities.Media {
+		entity := MediaEntity{
+			DisplayURL:  media.DisplayURL,
+			ExpandedURL: media.ExpandedURL,
+			ID:          media.IDStr,
+			MediaURL:    media.MediaURLHttps,
+			Type:        media.Type,
+			URL:         media.URL,
+		}
+		if !mediaSpan && add("media", len(tw.Entities.Media), media.Indices, media.URL) {
+			s := spans[len(spans)-1]
+			entity.Indices = [2]int{offsets[s.start], offsets[s.end]}
+			mediaSpan = true
+		} else if mediaSpan {
+			entity.Indices = tw.Entities.Media[0].Indices
+		}
+		tw.Entities.Media = append(tw.Entities.Media, entity)
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	displayStart, displayEnd := 0, len(text)
+	if len(tweet.DisplayTextRange) == 2 {
+		start, end := tweet.DisplayTextRange[0], tweet.DisplayTextRange[1]
+		if start >= 0 && start <= end && end <= len(text) {
+			displayStart, displayEnd = start, end
+		}
+	}
+	tw.DisplayTextRange = [2]int{offsets[displayStart], offsets[displayEnd]}
+
+	tw.HTML = renderHTML(tw, text, spans)
+	tw.DisplayText = renderDisplayText(tw, text, spans, displayStart, displayEnd)
+}
+
+func renderHTML(tw *Tweet, text []rune, spans []entitySpan) string {
+	var out strings.Builder
+	var foundedMedia []string
+	pos := 0
+	for _, span := range spans {
+		out.WriteString(htmlEscaper.Replace(string(text[pos:span.start])))
+		token := htmlEscaper.Replace(string(text[span.start:span.end]))
+		switch span.kind {
+		case "hashtag":
+			fmt.Fprintf(&out, `<a href="https://twitter.com/hashtag/%s">%s</a>`, url.QueryEscape(tw.Entities.Hashtags[span.index].Text), token)
+		case "cashtag":
+			fmt.Fprintf(&out, `<a href="https://twitter.com/search?q=%s">%s</a>`, url.QueryEscape("$"+tw.Entities.Cashtags[span.index].Text), token)
+		case "mention":
+			fmt.Fprintf(&out, `<a href="https://twitter.com/%s">%s</a>`, url.QueryEscape(tw.Entities.Mentions[span.index].Username), token)
+		case "url":
+			fmt.Fprintf(&out, `<a href="%s">%s</a>`, html.EscapeString(tw.Entities.URLs[span.index].ExpandedURL), token)
+		case "media":
+			media := tw.Entities.Media[span.index]
+			foundedMedia = append(foundedMedia, media.MediaURL)
+			fmt.Fprintf(&out, `<br><a href="%s"><img src="%s"/></a>`, html.EscapeString(media.URL), html.EscapeString(media.MediaURL))
+		}
+		pos = span.end
+	}
+	out.WriteString(htmlEscaper.Replace(string(text[pos:])))
+
+	for _, photo := range tw.Photos {
+		if !stringInSlice(photo.URL, foundedMedia) {
+			fmt.Fprintf(&out, `<br><img src="%s"/>`, html.EscapeString(photo.URL))
+		}
+	}
+	for _, video := range tw.Videos {
+		if !stringInSlice(video.Preview, foundedMedia) {
+			fmt.Fprintf(&out, `<br><img src="%s"/>`, html.EscapeString(video.Preview))
+		}
+	}
+	for _, gif := range tw.GIFs {
+		if !stringInSlice(gif.Preview, foundedMedia) {
+			fmt.Fprintf(&out, `<br><img src="%s"/>`, html.EscapeString(gif.Preview))
+		}
+	}
+	return strings.Replace(out.String(), "\n", "<br>", -1)
+}
+
+// renderDisplayText returns plain text inside of display range with expanded links
+func renderDisplayText(tw *Tweet, text []rune, spans []entitySpan, start, end int) string {
+	var display strings.Builder
+	pos := start
+	for _, span := range spans {
+		if span.start < start || span.end > end {
+			continue
+		}
+		display.WriteString(string(text[pos:span.start]))
+		switch span.kind {
+		case "url":
+			display.WriteString(tw.Entities.URLs[span.index].ExpandedURL)
+		case "media":
+		default:
+			display.WriteString(string(text[span.start:span.end]))
+		}
+		pos = span.end
+	}
+	display.WriteString(string(text[pos:end]))
+	return strings.TrimSpace(display.String())
+}
+
+// locateEntity checks the entity indices against its token in the text.
+// If they don't match, the token is searched in the text to fix the indices.
+func locateEntity(text []rune, used []bool, indices [2]int, token string) (int, int, bool) {
+	start, end := indices[0], indices[1]
+	if token != "" {
+		length := utf8.RuneCountInString(token)
+		if start >= 0 && start+length <= len(text) && strings.EqualFold(string(text[start:start+length]), token) &&
+			isFree(used, start, start+length) {
+			return start, start + length, true
+		}
+		lowerToken := strings.ToLower(token)
+		for from := 0; from+length <= len(text); {
+			i := strings.Index(strings.ToLower(string(text[from:])), lowerToken)
+			if i < 0 {
+				break
+			}
+			found := from + utf8.RuneCountInString(strings.ToLower(string(text[from:]))[:i])
+			if isFree(used, found, found+length) {
+				return found, found + length, true
+			}
+			from = found + 1
+		}
+	}
+	if start >= 0 && start < end && end <= len(text) {
+		return start, end, isFree(used, start, end)
+	}
+	return 0, 0, false
+}
+
+func isFree(used []bool, start, end int) bool {
+	for i := start; i < end; i++ {
+		if used[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// utf16Offsets returns offset in UTF-16 code units for each rune position of the text
+func utf16Offsets(text []rune) []int {
+	offsets := make([]int, len(text)+1)
+	for i, r := range text {
+		offsets[i+1] = offsets[i] + 1
+		if r >= 0x10000 {
+			offsets[i+1]++
+		}
+	}
+	return offsets
+}
@@ -0,0 +1,35 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenderHTMLEscapesAttributes(t *testing.T) {
+	var tweet legacyTweet
+	err := json.Unmarshal([]byte(`{
+		"full_text": "see https://t.co/abc #tag",
+		"entities": {
+			"hashtags": [{"indices": [22, 26], "text": "tag"}],
+			"urls": [{
+				"display_url": "evil.example",
+				"expanded_url": "https://evil.example/\"><script>alert(1)</script>",
+				"indices": [4, 21],
+				"url": "https://t.co/abc"
+			}]
+		}
+	}`), &tweet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := &Tweet{}
+	parseEntities(tw, &tweet)
+	if strings.Contains(tw.HTML, "<script>") || strings.Contains(tw.HTML, `/">`) {
+		t.Errorf("Expected expanded URL is escaped, got %s", tw.HTML)
+	}
+	expected := `see <a href="https://evil.example/&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;">https://t.co/abc</a> <a href="https://twitter.com/hashtag/tag">#tag</a>`
+	if tw.HTML != expected {
+		t.Errorf("Expected HTML %s, got %s", expected, tw.HTML)
+	}
+}
@@ -3,7 +3,6 @@ package twitterscraper
 import (
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 )
 
@@ -85,8 +84,9 @@ type timelineV1 struct {
 
 func (timeline *timelineV1) parseTweet(id string) *Tweet {
 	if tweet, ok := timeline.GlobalObjects.Tweets[id]; ok {
-		username := timeline.GlobalObjects.Users[tweet.UserIDStr].ScreenName
-		name := timeline.GlobalObjects.Users[tweet.UserIDStr].Name
+		user := timeline.GlobalObjects.Users[tweet.UserIDStr]
+		username := user.ScreenName
+		name := user.Name
 		tw := &Tweet{
 			ID:             id,
 			ConversationID: tweet.ConversationIDStr,
@@ -110,6 +110,12 @@ func (timeline *timelineV1) parseTweet(id string) *Tweet {
 			tw.Place = &tweet.Place
 		}
 
+		if user.ScreenName != "" {
+			author := parseProfile(user)
+			tw.Author = &author
+		}
+		parseTweetDetails(tw, &tweet)
+
 		if tweet.QuotedStatusIDStr != "" {
 			tw.IsQuoted = true
 			tw.QuotedStatus = timeline.parseTweet(tweet.QuotedStatusIDStr)
@@ -138,7 +144,7 @@ func (timeline *timelineV1) parseTweet(id string) *Tweet {
 			tw.Views = views
 		}
 
-		for _, pinned := range timeline.GlobalObjects.Users[tweet.UserIDStr].PinnedTweetIdsStr {
+		for _, pinned := range user.PinnedTweetIdsStr {
 			if tweet.IDStr == pinned {
 				tw.IsPin = true
 				break
@@ -157,84 +163,17 @@ func (timeline *timelineV1) parseTweet(id string) *Tweet {
 			})
 		}
 
-		for _, media := range tweet.ExtendedEntities.Media {
-			if media.Type == "photo" {
-				photo := Photo{
-					ID:  media.IDStr,
-					URL: media.MediaURLHttps,
-				}
-
-				tw.Photos = append(tw.Photos, photo)
-			} else if media.Type == "video" {
-				video := Video{
-					ID:      media.IDStr,
-					Preview: media.MediaURLHttps,
-				}
-
-				maxBitrate := 0
-				for _, variant := range media.VideoInfo.Variants {
-					if variant.Bitrate > maxBitrate {
-						video.URL = strings.TrimSuffix(variant.URL, "?tag=10")
-						maxBitrate = variant.Bitrate
-					}
-				}
-
-				tw.Videos = append(tw.Videos, video)
-			}
-
-			if !tw.SensitiveContent {
-				sensitive := media.ExtSensitiveMediaWarning
-				tw.SensitiveContent = sensitive.AdultContent || sensitive.GraphicViolence || sensitive.Other
-			}
-		}
+		parseMedia(tw, &tweet)
 
 		for _, url := range tweet.Entities.URLs {
 			tw.URLs = append(tw.URLs, url.ExpandedURL)
 		}
+		tw.SpaceID = parseSpaceID(tw.URLs)
+		parseCard(&tweet.Card, &tweet, tw)
+		tweet.Ext.EditControl.R.Ok.parse(tw)
+		tw.CommunityNote = tweet.Ext.BirdwatchPivot.R.Ok.parse()
 
-		tw.HTML = tweet.FullText
-		tw.HTML = reHashtag.ReplaceAllStringFunc(tw.HTML, func(hashtag string) string {
-			return fmt.Sprintf(`<a href="https://twitter.com/hashtag/%s">%s</a>`,
-				strings.TrimPrefix(hashtag, "#"),
-				hashtag,
-			)
-		})
-		tw.HTML = reUsername.ReplaceAllStringFunc(tw.HTML, func(username string) string {
-			return fmt.Sprintf(`<a href="https://twitter.com/%s">%s</a>`,
-				strings.TrimPrefix(username, "@"),
-				username,
-			)
-		})
-		var foundedMedia []string
-		tw.HTML = reTwitterURL.ReplaceAllStringFunc(tw.HTML, func(tco string) string {
-			for _, entity := range tweet.Entities.URLs {
-				if tco == entity.URL {
-					return fmt.Sprintf(`<a href="%s">%s</a>`, entity.ExpandedURL, tco)
-				}
-			}
-			for _, entity := range tweet.ExtendedEntities.Media {
-				if tco == entity.URL {
-					foundedMedia = append(foundedMedia, entity.MediaURLHttps)
-					return fmt.Sprintf(`<br><a href="%s"><img src="%s"/></a>`, tco, entity.MediaURLHttps)
-				}
-			}
-			return tco
-		})
-		for _, photo := range tw.Photos {
-			url := photo.URL
-			if stringInSlice(url, foundedMedia) {
-				continue
-			}
-			tw.HTML += fmt.Sprintf(`<br><img src="%s"/>`, url)
-		}
-		for _, video := range tw.Videos {
-			url := video.Preview
-			if stringInSlice(url, foundedMedia) {
-				continue
-			}
-			tw.HTML += fmt.Sprintf(`<br><img src="%s"/>`, url)
-		}
-		tw.HTML = strings.Replace(tw.HTML, "\n", "<br>", -1)
+		parseEntities(tw, &tweet)
 		return tw
 	}
 	return nil
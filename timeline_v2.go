@@ -1,26 +1,36 @@
 package twitterscraper
 
 import (
+	"encoding/json"
 	"strconv"
+	"strings"
 )
 
 type result struct {
-	Typename string `json:"__typename"`
-	Core     struct {
+	Typename       string         `json:"__typename"`
+	BirdwatchPivot birdwatchPivot `json:"birdwatch_pivot"`
+	Card           struct {
+		Legacy legacyCard `json:"legacy"`
+	} `json:"card"`
+	CommunityResults struct {
+		Result communityInfo `json:"result"`
+	} `json:"community_results"`
+	Core struct {
 		UserResults struct {
-			Result struct {
-				IsBlueVerified bool       `json:"is_blue_verified"`
-				Legacy         legacyUser `json:"legacy"`
-			} `json:"result"`
+			Result userResult `json:"result"`
 		} `json:"user_results"`
 	} `json:"core"`
-	Views struct {
+	EditControl    editControl `json:"edit_control"`
+	IsTranslatable bool        `json:"is_translatable"`
+	Source         string      `json:"source"`
+	Views          struct {
 		Count string `json:"count"`
 	} `json:"views"`
 	NoteTweet struct {
 		NoteTweetResults struct {
 			Result struct {
-				Text string `json:"text"`
+				EntitySet legacyEntities `json:"entity_set"`
+				Text      string         `json:"text"`
 			} `json:"result"`
 		} `json:"note_tweet_results"`
 	} `json:"note_tweet"`
@@ -28,55 +38,160 @@ type result struct {
 		Result *result `json:"result"`
 	} `json:"quoted_status_result"`
 	Legacy legacyTweet `json:"legacy"`
+	// TweetWithVisibilityResults wraps the tweet with limited actions
+	Tweet                *result `json:"tweet"`
+	LimitedActionResults struct {
+		LimitedActions []struct {
+			Action string `json:"action"`
+			Prompt struct {
+				Headline struct {
+					Text string `json:"text"`
+				} `json:"headline"`
+			} `json:"prompt"`
+		} `json:"limited_actions"`
+	} `json:"limitedActionResults"`
+	// TweetTombstone and TweetUnavailable
+	Tombstone struct {
+		Text struct {
+			Text string `json:"text"`
+		} `json:"text"`
+	} `json:"tombstone"`
+	Reason string `json:"reason"`
 }
 
 func (result *result) parse() *Tweet {
-	if result.NoteTweet.NoteTweetResults.Result.Text != "" {
-		result.Legacy.FullText = result.NoteTweet.NoteTweetResults.Result.Text
+	switch result.Typename {
+	case "TweetWithVisibilityResults":
+		if result.Tweet == nil {
+			return nil
+		}
+		tw := result.Tweet.parse()
+		if tw == nil {
+			return nil
+		}
+		for _, limited := range result.LimitedActionResults.LimitedActions {
+			tw.LimitedActions = append(tw.LimitedActions, limited.Action)
+			if tw.VisibilityLabel == "" {
+				tw.VisibilityLabel = limited.Prompt.Headline.Text
+			}
+		}
+		return tw
+	case "TweetTombstone", "TweetUnavailable":
+		return &Tweet{
+			Text:        result.Tombstone.Text.Text,
+			Unavailable: parseUnavailableReason(result.Reason, result.Tombstone.Text.Text),
+		}
+	}
+	if note := result.NoteTweet.NoteTweetResults.Result; note.Text != "" {
+		// long tweet has its own entities, media are kept from the legacy
+		note.EntitySet.Media = result.Legacy.Entities.Media
+		result.Legacy.FullText = note.Text
+		result.Legacy.Entities = note.EntitySet
+		result.Legacy.DisplayTextRange = nil
 	}
 	tw := parseLegacyTweet(&result.Core.UserResults.Result.Legacy, &result.Legacy)
 	if tw == nil {
 		return nil
 	}
+	if author := result.Core.UserResults.Result.parse(); author.Username != "" {
+		tw.Author = &author
+	}
+	if tw.Source == "" {
+		tw.Source = parseSource(result.Source)
+	}
+	tw.IsTranslatable = tw.IsTranslatable || result.IsTranslatable
 	if tw.Views == 0 && result.Views.Count != "" {
 		tw.Views, _ = strconv.Atoi(result.Views.Count)
 	}
 	if result.QuotedStatusResult.Result != nil {
 		tw.QuotedStatus = result.QuotedStatusResult.Result.parse()
+		if tw.QuotedStatus != nil && tw.QuotedStatus.ID == "" {
+			tw.QuotedStatus.setUnavailableID(tw.QuotedStatusID)
+		}
 	}
+	tw.CommunityID = result.CommunityResults.Result.IDStr
+	parseCard(&result.Card.Legacy, &result.Legacy, tw)
+	result.EditControl.parse(tw)
+	tw.CommunityNote = result.BirdwatchPivot.parse()
 	return tw
 }
 
+// parseEntry parses tweet result of timeline entry,
+// ID of unavailable tweet is taken from the entry ID
+func (result *result) parseEntry(entryID string) *Tweet {
+	tw := result.parse()
+	if tw == nil || tw.ID != "" {
+		return tw
+	}
+	// entry ID looks like "tweet-123" or "conversationthread-123-tweet-456"
+	i := strings.LastIndex(entryID, "tweet-")
+	if i < 0 {
+		return nil
+	}
+	id := entryID[i+len("tweet-"):]
+	if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+		return nil
+	}
+	tw.setUnavailableID(id)
+	return tw
+}
+
+type userResult struct {
+	RestID         string     `json:"rest_id"`
+	IsBlueVerified bool       `json:"is_blue_verified"`
+	Legacy         legacyUser `json:"legacy"`
+}
+
+func (user *userResult) parse() Profile {
+	profile := parseProfile(user.Legacy)
+	if profile.UserID == "" {
+		profile.UserID = user.RestID
+	}
+	profile.IsBlueVerified = profile.IsBlueVerified || user.IsBlueVerified
+	return profile
+}
+
+type itemContent struct {
+	PromotedMetadata json.RawMessage `json:"promotedMetadata"`
+	TweetDisplayType string          `json:"tweetDisplayType"`
+	TweetResults     struct {
+		Result result `json:"result"`
+	} `json:"tweet_results"`
+	UserDisplayType string `json:"userDisplayType"`
+	UserResults     struct {
+		Result userResult `json:"result"`
+	} `json:"user_results"`
+	List listInfo `json:"list"`
+}
+
+func (content *itemContent) isPromoted() bool {
+	return len(content.PromotedMetadata) > 0 && string(content.PromotedMetadata) != "null"
+}
+
+type moduleItem struct {
+	EntryID string `json:"entryId"`
+	Item    struct {
+		ItemContent itemContent `json:"itemContent"`
+	} `json:"item"`
+}
+
 type entry struct {
+	EntryID string `json:"entryId"`
 	Content struct {
-		CursorType string `json:"cursorType"`
-		Value      string `json:"value"`
-		Items      []struct {
-			Item struct {
-				ItemContent struct {
-					TweetDisplayType string `json:"tweetDisplayType"`
-					TweetResults     struct {
-						Result result `json:"result"`
-					} `json:"tweet_results"`
-				} `json:"itemContent"`
-			} `json:"item"`
-		} `json:"items"`
-		ItemContent struct {
-			TweetDisplayType string `json:"tweetDisplayType"`
-			TweetResults     struct {
-				Result result `json:"result"`
-			} `json:"tweet_results"`
-			UserDisplayType string `json:"userDisplayType"`
-			UserResults     struct {
-				Result struct {
-					RestID string     `json:"rest_id"`
-					Legacy legacyUser `json:"legacy"`
-				} `json:"result"`
-			} `json:"user_results"`
-		} `json:"itemContent"`
+		CursorType  string       `json:"cursorType"`
+		Value       string       `json:"value"`
+		Items       []moduleItem `json:"items"`
+		ItemContent itemContent  `json:"itemContent"`
 	} `json:"content"`
 }
 
+type instruction struct {
+	Type        string       `json:"type"`
+	Entries     []entry      `json:"entries"`
+	Entry       entry        `json:"entry"`
+	ModuleItems []moduleItem `json:"moduleItems"`
+}
+
 // timeline v2 JSON object
 type timelineV2 struct {
 	Data struct {
@@ -84,11 +199,7 @@ type timelineV2 struct {
 			Result struct {
 				TimelineV2 struct {
 					Timeline struct {
-						Instructions []struct {
-							Entries []entry `json:"entries"`
-							Entry   entry   `json:"entry"`
-							Type    string  `json:"type"`
-						} `json:"instructions"`
+						Instructions []instruction `json:"instructions"`
 					} `json:"timeline"`
 				} `json:"timeline_v2"`
 			} `json:"result"`
@@ -97,32 +208,114 @@ type timelineV2 struct {
 }
 
 func (timeline *timelineV2) parseTweets() ([]*Tweet, string) {
+	return parseTimelineTweets(timeline.Data.User.Result.TimelineV2.Timeline.Instructions, false)
+}
+
+// parseTimelineTweets returns tweets and bottom cursor from GraphQL timeline instructions.
+// Promoted tweets are skipped unless includePromoted is set.
+func parseTimelineTweets(instructions []instruction, includePromoted bool) ([]*Tweet, string) {
 	var cursor string
 	var tweets []*Tweet
-	for _, instruction := range timeline.Data.User.Result.TimelineV2.Timeline.Instructions {
+	for _, instruction := range instructions {
+		if instruction.Entry.Content.CursorType == "Bottom" {
+			cursor = instruction.Entry.Content.Value
+		}
 		for _, entry := range instruction.Entries {
 			if entry.Content.CursorType == "Bottom" {
 				cursor = entry.Content.Value
 				continue
 			}
-			if entry.Content.ItemContent.TweetResults.Result.Typename == "Tweet" {
-				if tweet := entry.Content.ItemContent.TweetResults.Result.parse(); tweet != nil {
-					tweets = append(tweets, tweet)
+			if !includePromoted && (strings.HasPrefix(entry.EntryID, "promoted-") || entry.Content.ItemContent.isPromoted()) {
+				continue
+			}
+			if tweet := entry.Content.ItemContent.TweetResults.Result.parseEntry(entry.EntryID); tweet != nil {
+				tweets = append(tweets, tweet)
+			}
+			// module entries (media grid, conversations) keep their tweets in items
+			var module []*Tweet
+			for _, item := range entry.Content.Items {
+				if !includePromoted && item.Item.ItemContent.isPromoted() {
+					continue
+				}
+				if tweet := item.Item.ItemContent.TweetResults.Result.parseEntry(item.EntryID); tweet != nil {
+					module = append(module, tweet)
 				}
 			}
+			for _, tweet := range module {
+				if tweet.InReplyToStatusID != "" {
+					for _, parentTweet := range module {
+						if parentTweet.ID == tweet.InReplyToStatusID {
+							tweet.InReplyToStatus = parentTweet
+							break
+						}
+					}
+				}
+			}
+			tweets = append(tweets, module...)
+		}
+		// next pages of a module come as TimelineAddToModule instruction
+		for _, item := range instruction.ModuleItems {
+			if tweet := item.Item.ItemContent.TweetResults.Result.parseEntry(item.EntryID); tweet != nil {
+				tweets = append(tweets, tweet)
+			}
 		}
 	}
 	return tweets, cursor
 }
 
+// parseTimelineProfiles returns profiles and bottom cursor from GraphQL timeline instructions.
+func parseTimelineProfiles(instructions []instruction) ([]*Profile, string) {
+	var cursor string
+	var profiles []*Profile
+	for _, instruction := range instructions {
+		if instruction.Entry.Content.CursorType == "Bottom" {
+			cursor = instruction.Entry.Content.Value
+		}
+		for _, entry := range instruction.Entries {
+			if entry.Content.CursorType == "Bottom" {
+				cursor = entry.Content.Value
+				continue
+			}
+			if entry.Content.ItemContent.UserDisplayType == "User" {
+				if profile := entry.Content.ItemContent.UserResults.Result.parse(); profile.Username != "" {
+					profiles = append(profiles, &profile)
+				}
+			}
+		}
+	}
+	return profiles, cursor
+}
+
+// parseTimelineLists returns lists and bottom cursor from GraphQL timeline instructions.
+func parseTimelineLists(instructions []instruction) ([]*List, string) {
+	var cursor string
+	var lists []*List
+	for _, instruction := range instructions {
+		if instruction.Entry.Content.CursorType == "Bottom" {
+			cursor = instruction.Entry.Content.Value
+		}
+		for _, entry := range instruction.Entries {
+			if entry.Content.CursorType == "Bottom" {
+				cursor = entry.Content.Value
+				continue
+			}
+			if list := entry.Content.ItemContent.List.parse(); list != nil {
+				lists = append(lists, list)
+			}
+			for _, item := range entry.Content.Items {
+				if list := item.Item.ItemContent.List.parse(); list != nil {
+					lists = append(lists, list)
+				}
+			}
+		}
+	}
+	return lists, cursor
+}
+
 type threadedConversation struct {
 	Data struct {
 		ThreadedConversationWithInjectionsV2 struct {
-			Instructions []struct {
-				Type    string  `json:"type"`
-				Entries []entry `json:"entries"`
-				Entry   entry   `json:"entry"`
-			} `json:"instructions"`
+			Instructions []instruction `json:"instructions"`
 		} `json:"threaded_conversation_with_injections_v2"`
 	} `json:"data"`
 }
@@ -131,22 +324,18 @@ func (conversation *threadedConversation) parse() []*Tweet {
 	var tweets []*Tweet
 	for _, instruction := range conversation.Data.ThreadedConversationWithInjectionsV2.Instructions {
 		for _, entry := range instruction.Entries {
-			if entry.Content.ItemContent.TweetResults.Result.Typename == "Tweet" {
-				if tweet := entry.Content.ItemContent.TweetResults.Result.parse(); tweet != nil {
-					if entry.Content.ItemContent.TweetDisplayType == "SelfThread" {
-						tweet.IsSelfThread = true
-					}
-					tweets = append(tweets, tweet)
+			if tweet := entry.Content.ItemContent.TweetResults.Result.parseEntry(entry.EntryID); tweet != nil {
+				if entry.Content.ItemContent.TweetDisplayType == "SelfThread" {
+					tweet.IsSelfThread = true
 				}
+				tweets = append(tweets, tweet)
 			}
 			for _, item := range entry.Content.Items {
-				if item.Item.ItemContent.TweetResults.Result.Typename == "Tweet" {
-					if tweet := item.Item.ItemContent.TweetResults.Result.parse(); tweet != nil {
-						if item.Item.ItemContent.TweetDisplayType == "SelfThread" {
-							tweet.IsSelfThread = true
-						}
-						tweets = append(tweets, tweet)
+				if tweet := item.Item.ItemContent.TweetResults.Result.parseEntry(item.EntryID); tweet != nil {
+					if item.Item.ItemContent.TweetDisplayType == "SelfThread" {
+						tweet.IsSelfThread = true
 					}
+					tweets = append(tweets, tweet)
 				}
 			}
 		}
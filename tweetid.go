@@ -0,0 +1,91 @@
+package twitterscraper
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// twitter snowflake epoch in milliseconds (2010-11-04T01:42:54.657Z)
+	snowflakeEpoch = 1288834974657
+	// the first tweet ID generated by snowflake, older IDs are sequential
+	firstSnowflakeID = 29700859247
+)
+
+// hosts serving tweet links
+var tweetHosts = map[string]bool{
+	"twitter.com":        true,
+	"www.twitter.com":    true,
+	"mobile.twitter.com": true,
+	"x.com":              true,
+	"www.x.com":          true,
+	"mobile.x.com":       true,
+	"fxtwitter.com":      true,
+	"vxtwitter.com":      true,
+	"fixupx.com":         true,
+	"fixvx.com":          true,
+}
+
+// ParseTweetURL returns username and tweet ID from tweet link.
+// Username is empty for links like https://twitter.com/i/web/status/ID.
+func ParseTweetURL(link string) (username string, id string, err error) {
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", "", err
+	}
+	if !tweetHosts[strings.ToLower(u.Hostname())] {
+		return "", "", fmt.Errorf("not a tweet URL: %s", link)
+	}
+	// /user/status/ID, /user/statuses/ID, /i/web/status/ID, /i/status/ID
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 1; i+1 < len(parts); i++ {
+		if parts[i] != "status" && parts[i] != "statuses" {
+			continue
+		}
+		if _, err := strconv.ParseUint(parts[i+1], 10, 64); err != nil {
+			break
+		}
+		if parts[0] != "i" {
+			username = parts[0]
+		}
+		return username, parts[i+1], nil
+	}
+	return "", "", fmt.Errorf("not a tweet URL: %s", link)
+}
+
+// TweetIDTime returns creation time of the tweet decoded from its ID
+func TweetIDTime(id string) (time.Time, error) {
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if n < firstSnowflakeID {
+		return time.Time{}, fmt.Errorf("tweet ID %s has no timestamp", id)
+	}
+	msec := int64(n>>22) + snowflakeEpoch
+	return time.Unix(0, msec*int64(time.Millisecond)).UTC(), nil
+}
+
+// MinTweetID returns the smallest tweet ID created at the time (millisecond precision)
+func MinTweetID(t time.Time) string {
+	msec := t.UnixNano()/int64(time.Millisecond) - snowflakeEpoch
+	if msec < 0 {
+		return "0"
+	}
+	return strconv.FormatUint(uint64(msec)<<22, 10)
+}
+
+// MaxTweetID returns the largest tweet ID created at the time (millisecond precision)
+func MaxTweetID(t time.Time) string {
+	msec := t.UnixNano()/int64(time.Millisecond) - snowflakeEpoch
+	if msec < 0 {
+		return strconv.FormatUint(firstSnowflakeID-1, 10)
+	}
+	return strconv.FormatUint(uint64(msec)<<22|(1<<22-1), 10)
+}
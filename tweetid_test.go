@@ -0,0 +1,68 @@
+package twitterscraper_test
+
+import (
+	"testing"
+	"time"
+
+	twitterscraper "github.com/n0madic/twitter-scraper"
+)
+
+func TestParseTweetURL(t *testing.T) {
+	tests := []struct {
+		link     string
+		username string
+		id       string
+		wantErr  bool
+	}{
+		{"https://twitter.com/Twitter/status/1328684389388185600", "Twitter", "1328684389388185600", false},
+		{"https://x.com/Twitter/status/1328684389388185600?s=20", "Twitter", "1328684389388185600", false},
+		{"https://mobile.twitter.com/Twitter/status/1328684389388185600/photo/1", "Twitter", "1328684389388185600", false},
+		{"https://fxtwitter.com/Twitter/status/1328684389388185600", "Twitter", "1328684389388185600", false},
+		{"twitter.com/Twitter/statuses/1328684389388185600", "Twitter", "1328684389388185600", false},
+		{"https://twitter.com/i/web/status/1328684389388185600", "", "1328684389388185600", false},
+		{"https://twitter.com/Twitter", "", "", true},
+		{"https://twitter.com/Twitter/status/abc", "", "", true},
+		{"https://example.com/Twitter/status/1328684389388185600", "", "", true},
+	}
+	for _, test := range tests {
+		username, id, err := twitterscraper.ParseTweetURL(test.link)
+		if (err != nil) != test.wantErr {
+			t.Errorf("ParseTweetURL(%q) error = %v", test.link, err)
+			continue
+		}
+		if username != test.username || id != test.id {
+			t.Errorf("ParseTweetURL(%q) = %q, %q, expected %q, %q", test.link, username, id, test.username, test.id)
+		}
+	}
+}
+
+func TestTweetIDTime(t *testing.T) {
+	tm, err := twitterscraper.TweetIDTime("1328684389388185600")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := time.Date(2020, 11, 17, 13, 0, 18, 0, time.UTC)
+	if tm.Truncate(time.Second) != expected {
+		t.Errorf("Expected time %v, got %v", expected, tm)
+	}
+	if _, err := twitterscraper.TweetIDTime("20"); err == nil {
+		t.Error("Expected error for pre-snowflake tweet ID")
+	}
+}
+
+func TestMinMaxTweetID(t *testing.T) {
+	tm := time.Date(2020, 11, 17, 13, 0, 18, 0, time.UTC)
+	minID, maxID := twitterscraper.MinTweetID(tm), twitterscraper.MaxTweetID(tm)
+	if minID >= maxID || len(minID) != len(maxID) {
+		t.Errorf("Expected MinTweetID %s < MaxTweetID %s", minID, maxID)
+	}
+	for _, id := range []string{minID, maxID} {
+		idTime, err := twitterscraper.TweetIDTime(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !idTime.Equal(tm) {
+			t.Errorf("Expected time of ID %s is %v, got %v", id, tm, idTime)
+		}
+	}
+}
@@ -5,11 +5,24 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
+)
+
+const (
+	userTweetsURL           = "https://twitter.com/i/api/graphql/UGi7tjRPr-d_U3bCPIko5Q/UserTweets"
+	userTweetsAndRepliesURL = "https://twitter.com/i/api/graphql/pz0IHaV_t7T4HJavqqqcIA/UserTweetsAndReplies"
+	userMediaURL            = "https://twitter.com/i/api/graphql/Le6KlbilFmSu-5VltFND-Q/UserMedia"
 )
 
 // GetTweets returns channel with tweets for a given user.
 func (s *Scraper) GetTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchTweets)
+	return getTweetTimeline(ctx, user, maxTweetsNbr, timelineFilter{}, s.FetchTweets)
+}
+
+// GetTweetsWithOptions returns channel with tweets for a given user, filtered by options.
+// The timeline is over on the first tweet older than opts.Since.
+func (s *Scraper) GetTweetsWithOptions(ctx context.Context, user string, maxTweetsNbr int, opts TimelineOptions) <-chan *TweetResult {
+	return getTweetTimeline(ctx, user, maxTweetsNbr, opts.timelineFilter(true), s.FetchTweets)
 }
 
 // FetchTweets gets tweets for a given user, via the Twitter frontend API.
@@ -26,12 +39,44 @@ func (s *Scraper) FetchTweets(user string, maxTweetsNbr int, cursor string) ([]*
 }
 
 // FetchTweetsByUserID gets tweets for a given userID, via the Twitter frontend GraphQL API.
+// If replies are enabled with WithReplies, the tweets and replies timeline is used.
 func (s *Scraper) FetchTweetsByUserID(userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	if s.includeReplies {
+		return s.fetchUserTimeline(userTweetsAndRepliesURL, userID, maxTweetsNbr, cursor)
+	}
+	return s.fetchUserTimeline(userTweetsURL, userID, maxTweetsNbr, cursor)
+}
+
+// GetMediaTweets returns channel with tweets containing media for a given user.
+func (s *Scraper) GetMediaTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
+	return getTweetTimeline(ctx, user, maxTweetsNbr, timelineFilter{}, s.FetchMediaTweets)
+}
+
+// FetchMediaTweets gets tweets with media for a given user, via the Twitter frontend API.
+func (s *Scraper) FetchMediaTweets(user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	userID, err := s.GetUserIDByScreenName(user)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if s.isOpenAccount {
+		return s.FetchMediaTweetsByUserIDLegacy(userID, maxTweetsNbr, cursor)
+	}
+	return s.FetchMediaTweetsByUserID(userID, maxTweetsNbr, cursor)
+}
+
+// FetchMediaTweetsByUserID gets tweets with media for a given userID, via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchMediaTweetsByUserID(userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchUserTimeline(userMediaURL, userID, maxTweetsNbr, cursor)
+}
+
+// fetchUserTimeline gets a page of the user timeline (UserTweets, UserMedia, etc.) from GraphQL endpoint.
+func (s *Scraper) fetchUserTimeline(endpoint string, userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxTweetsNbr > 200 {
 		maxTweetsNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/UGi7tjRPr-d_U3bCPIko5Q/UserTweets")
+	req, err := s.newRequest("GET", endpoint)
 	if err != nil {
 		return nil, "", err
 	}
@@ -40,11 +85,35 @@ func (s *Scraper) FetchTweetsByUserID(userID string, maxTweetsNbr int, cursor st
 		"userId":                                 userID,
 		"count":                                  maxTweetsNbr,
 		"includePromotedContent":                 false,
+		"withBirdwatchNotes":                     true,
+		"withCommunity":                          true,
 		"withQuickPromoteEligibilityTweetFields": false,
 		"withVoice":                              true,
 		"withV2Timeline":                         true,
 	}
-	features := map[string]interface{}{
+
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(timelineFeatures()))
+	req.URL.RawQuery = query.Encode()
+
+	var timeline timelineV2
+	err = s.RequestAPI(req, &timeline)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tweets, nextCursor := timeline.parseTweets()
+	return tweets, nextCursor, nil
+}
+
+// timelineFeatures returns GraphQL features for timeline requests
+func timelineFeatures() map[string]interface{} {
+	return map[string]interface{}{
 		"rweb_lists_timeline_redesign_enabled":                              true,
 		"responsive_web_graphql_exclude_directive_enabled":                  true,
 		"verified_phone_label_enabled":                                      false,
@@ -67,33 +136,24 @@ func (s *Scraper) FetchTweetsByUserID(userID string, maxTweetsNbr int, cursor st
 		"longform_notetweets_inline_media_enabled":                                false,
 		"responsive_web_enhance_cards_enabled":                                    false,
 	}
-
-	if cursor != "" {
-		variables["cursor"] = cursor
-	}
-
-	query := url.Values{}
-	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
-	req.URL.RawQuery = query.Encode()
-
-	var timeline timelineV2
-	err = s.RequestAPI(req, &timeline)
-	if err != nil {
-		return nil, "", err
-	}
-
-	tweets, nextCursor := timeline.parseTweets()
-	return tweets, nextCursor, nil
 }
 
 // FetchTweetsByUserIDLegacy gets tweets for a given userID, via the Twitter frontend legacy API.
 func (s *Scraper) FetchTweetsByUserIDLegacy(userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchUserTimelineLegacy("https://api.twitter.com/2/timeline/profile/"+userID+".json", userID, maxTweetsNbr, cursor)
+}
+
+// FetchMediaTweetsByUserIDLegacy gets tweets with media for a given userID, via the Twitter frontend legacy API.
+func (s *Scraper) FetchMediaTweetsByUserIDLegacy(userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchUserTimelineLegacy("https://api.twitter.com/2/timeline/media/"+userID+".json", userID, maxTweetsNbr, cursor)
+}
+
+func (s *Scraper) fetchUserTimelineLegacy(endpoint string, userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxTweetsNbr > 200 {
 		maxTweetsNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://api.twitter.com/2/timeline/profile/"+userID+".json")
+	req, err := s.newRequest("GET", endpoint)
 	if err != nil {
 		return nil, "", err
 	}
@@ -116,8 +176,19 @@ func (s *Scraper) FetchTweetsByUserIDLegacy(userID string, maxTweetsNbr int, cur
 	return tweets, nextCursor, nil
 }
 
-// GetTweet get a single tweet by ID.
+// GetTweet get a single tweet by ID or URL.
 func (s *Scraper) GetTweet(id string) (*Tweet, error) {
+	return s.getTweet(context.Background(), id)
+}
+
+func (s *Scraper) getTweet(ctx context.Context, id string) (*Tweet, error) {
+	if strings.Contains(id, "/") {
+		_, tweetID, err := ParseTweetURL(id)
+		if err != nil {
+			return nil, err
+		}
+		id = tweetID
+	}
 	if s.isOpenAccount {
 		req, err := s.newRequest("GET", "https://api.twitter.com/2/timeline/conversation/"+id+".json")
 		if err != nil {
@@ -125,7 +196,7 @@ func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 		}
 
 		var timeline timelineV1
-		err = s.RequestAPI(req, &timeline)
+		err = s.RequestAPI(req.WithContext(ctx), &timeline)
 		if err != nil {
 			return nil, err
 		}
@@ -137,74 +208,83 @@ func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 			}
 		}
 	} else {
-		req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/VWFGPVAGkZMGRKGe3GFFnA/TweetDetail")
+		conversation, err := s.fetchTweetDetail(ctx, id)
 		if err != nil {
 			return nil, err
 		}
 
-		variables := map[string]interface{}{
-			"focalTweetId":                           id,
-			"with_rux_injections":                    false,
-			"includePromotedContent":                 true,
-			"withCommunity":                          true,
-			"withQuickPromoteEligibilityTweetFields": true,
-			"withBirdwatchNotes":                     true,
-			"withVoice":                              true,
-			"withV2Timeline":                         true,
+		tweets := conversation.parse()
+		for _, tweet := range tweets {
+			if tweet.ID == id {
+				return tweet, nil
+			}
 		}
+	}
+	return nil, fmt.Errorf("tweet with ID %s not found", id)
+}
 
-		features := map[string]interface{}{
-			"rweb_lists_timeline_redesign_enabled":                                    true,
-			"responsive_web_graphql_exclude_directive_enabled":                        true,
-			"verified_phone_label_enabled":                                            false,
-			"creator_subscriptions_tweet_preview_api_enabled":                         true,
-			"responsive_web_graphql_timeline_navigation_enabled":                      true,
-			"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
-			"tweetypie_unmention_optimization_enabled":                                true,
-			"responsive_web_edit_tweet_api_enabled":                                   true,
-			"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-			"view_counts_everywhere_api_enabled":                                      true,
-			"longform_notetweets_consumption_enabled":                                 true,
-			"tweet_awards_web_tipping_enabled":                                        false,
-			"freedom_of_speech_not_reach_fetch_enabled":                               true,
-			"standardized_nudges_misinfo":                                             true,
-			"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": false,
-			"longform_notetweets_rich_text_read_enabled":                              true,
-			"longform_notetweets_inline_media_enabled":                                true,
-			"responsive_web_enhance_cards_enabled":                                    false,
-		}
+// fetchTweetDetail gets conversation of the focal tweet
+func (s *Scraper) fetchTweetDetail(ctx context.Context, id string) (*threadedConversation, error) {
+	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/VWFGPVAGkZMGRKGe3GFFnA/TweetDetail")
+	if err != nil {
+		return nil, err
+	}
 
-		query := url.Values{}
-		query.Set("variables", mapToJSONString(variables))
-		query.Set("features", mapToJSONString(features))
-		req.URL.RawQuery = query.Encode()
+	variables := map[string]interface{}{
+		"focalTweetId":                           id,
+		"with_rux_injections":                    false,
+		"includePromotedContent":                 true,
+		"withCommunity":                          true,
+		"withQuickPromoteEligibilityTweetFields": true,
+		"withBirdwatchNotes":                     true,
+		"withVoice":                              true,
+		"withV2Timeline":                         true,
+	}
 
-		var conversation threadedConversation
+	features := map[string]interface{}{
+		"rweb_lists_timeline_redesign_enabled":                                    true,
+		"responsive_web_graphql_exclude_directive_enabled":                        true,
+		"verified_phone_label_enabled":                                            false,
+		"creator_subscriptions_tweet_preview_api_enabled":                         true,
+		"responsive_web_graphql_timeline_navigation_enabled":                      true,
+		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+		"tweetypie_unmention_optimization_enabled":                                true,
+		"responsive_web_edit_tweet_api_enabled":                                   true,
+		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+		"view_counts_everywhere_api_enabled":                                      true,
+		"longform_notetweets_consumption_enabled":                                 true,
+		"tweet_awards_web_tipping_enabled":                                        false,
+		"freedom_of_speech_not_reach_fetch_enabled":                               true,
+		"standardized_nudges_misinfo":                                             true,
+		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": false,
+		"longform_notetweets_rich_text_read_enabled":                              true,
+		"longform_notetweets_inline_media_enabled":                                true,
+		"responsive_web_enhance_cards_enabled":                                    false,
+	}
 
-		// Surprisingly, if bearerToken2 is not set, then animated GIFs are not
-		// present in the response for tweets with a GIF + a photo like this one:
-		// https://twitter.com/Twitter/status/1580661436132757506
-		curBearerToken := s.bearerToken
-		if curBearerToken != bearerToken2 {
-			s.setBearerToken(bearerToken2)
-		}
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(features))
+	req.URL.RawQuery = query.Encode()
 
-		err = s.RequestAPI(req, &conversation)
+	var conversation threadedConversation
 
-		if curBearerToken != bearerToken2 {
-			s.setBearerToken(curBearerToken)
-		}
+	// Surprisingly, if bearerToken2 is not set, then animated GIFs are not
+	// present in the response for tweets with a GIF + a photo like this one:
+	// https://twitter.com/Twitter/status/1580661436132757506
+	curBearerToken := s.bearerToken
+	if curBearerToken != bearerToken2 {
+		s.setBearerToken(bearerToken2)
+	}
 
-		if err != nil {
-			return nil, err
-		}
+	err = s.RequestAPI(req.WithContext(ctx), &conversation)
 
-		tweets := conversation.parse()
-		for _, tweet := range tweets {
-			if tweet.ID == id {
-				return tweet, nil
-			}
-		}
+	if curBearerToken != bearerToken2 {
+		s.setBearerToken(curBearerToken)
 	}
-	return nil, fmt.Errorf("tweet with ID %s not found", id)
+
+	if err != nil {
+		return nil, err
+	}
+	return &conversation, nil
 }
@@ -2,6 +2,7 @@ package twitterscraper_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +15,13 @@ var cmpOptions = cmp.Options{
 	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Likes"),
 	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Replies"),
 	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Retweets"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "DisplayText", "DisplayTextRange", "Entities"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "EditableUntil", "EditHistoryIDs", "EditsRemaining", "IsEditEligible"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Author", "BookmarkCount", "InReplyToUserID", "InReplyToUsername", "IsTranslatable", "Lang"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "PossiblySensitive", "QuoteCount", "ReplyPolicy", "Source"),
+	cmpopts.IgnoreFields(twitterscraper.Photo{}, "AltText", "Height", "Sensitive", "Width"),
+	cmpopts.IgnoreFields(twitterscraper.Video{}, "AltText", "Duration", "Height", "HLSURL", "Sensitive", "Variants", "ViewCount", "Width"),
+	cmpopts.IgnoreFields(twitterscraper.GIF{}, "AltText", "Height", "Sensitive", "Variants", "Width"),
 }
 
 func TestGetTweets(t *testing.T) {
@@ -75,6 +83,60 @@ func TestGetTweets(t *testing.T) {
 	}
 }
 
+func TestGetTweetsWithReplies(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	testScraper.WithReplies(true)
+	defer testScraper.WithReplies(false)
+	replies := 0
+	for tweet := range testScraper.GetTweets(context.Background(), "TwitterSupport", 50) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if tweet.IsReply {
+			replies++
+			if tweet.InReplyToStatus != nil && tweet.InReplyToStatus.ID != tweet.InReplyToStatusID {
+				t.Errorf("Expected InReplyToStatus ID %s, got %s", tweet.InReplyToStatusID, tweet.InReplyToStatus.ID)
+			}
+		}
+	}
+	if replies == 0 {
+		t.Error("Expected replies in timeline")
+	}
+}
+
+func TestGetMediaTweets(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	count := 0
+	maxTweetsNbr := 50
+	dupcheck := make(map[string]bool)
+	for tweet := range testScraper.GetMediaTweets(context.Background(), "Twitter", maxTweetsNbr) {
+		if tweet.Error != nil {
+			t.Error(tweet.Error)
+		} else {
+			count++
+			if tweet.ID == "" {
+				t.Error("Expected tweet ID is empty")
+			} else {
+				if dupcheck[tweet.ID] {
+					t.Errorf("Detect duplicated tweet ID: %s", tweet.ID)
+				} else {
+					dupcheck[tweet.ID] = true
+				}
+			}
+			if len(tweet.Photos) == 0 && len(tweet.Videos) == 0 && len(tweet.GIFs) == 0 {
+				t.Errorf("Expected media in tweet %s", tweet.ID)
+			}
+		}
+	}
+	if count != maxTweetsNbr {
+		t.Errorf("Expected tweets count=%v, got: %v", maxTweetsNbr, count)
+	}
+}
+
 func assertGetTweet(t *testing.T, expectedTweet *twitterscraper.Tweet) {
 	actualTweet, err := testScraper.GetTweet(expectedTweet.ID)
 	if err != nil {
@@ -313,3 +375,134 @@ func TestTweetThread(t *testing.T) {
 		}
 	}
 }
+
+func TestTweetPoll(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	tweet, err := testScraper.GetTweet("1593767953706921985")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.Poll == nil {
+		t.Fatal("Expected tweet Poll is nil")
+	}
+	if len(tweet.Poll.Choices) != 2 {
+		t.Fatalf("Expected 2 poll choices, got %d", len(tweet.Poll.Choices))
+	}
+	if tweet.Poll.Choices[0].Label != "Yes" || tweet.Poll.Choices[1].Label != "No" {
+		t.Errorf("Unexpected poll choices %+v", tweet.Poll.Choices)
+	}
+	if !tweet.Poll.CountsAreFinal {
+		t.Error("Expected poll CountsAreFinal is true")
+	}
+	if tweet.Poll.TotalVotes == 0 {
+		t.Error("Expected poll TotalVotes is greater than zero")
+	}
+	if tweet.Poll.EndTime.IsZero() {
+		t.Error("Expected poll EndTime is zero")
+	}
+}
+
+func TestTweetEditHistory(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	id := "1606055187348688896"
+	tweet, err := testScraper.GetTweet(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweet.EditHistoryIDs) == 0 {
+		t.Fatal("Expected tweet EditHistoryIDs is empty")
+	}
+	if tweet.EditHistoryIDs[len(tweet.EditHistoryIDs)-1] != id {
+		t.Errorf("Expected last EditHistoryIDs is %s, got %v", id, tweet.EditHistoryIDs)
+	}
+	if tweet.EditableUntil.IsZero() {
+		t.Error("Expected tweet EditableUntil is zero")
+	}
+
+	versions, err := testScraper.GetTweetEditHistory(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != len(tweet.EditHistoryIDs) {
+		t.Errorf("Expected %d versions, got %d", len(tweet.EditHistoryIDs), len(versions))
+	}
+}
+
+func TestTweetEntities(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	tweet, err := testScraper.GetTweet("1288540609310056450")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []twitterscraper.HashtagEntity{{Indices: [2]int{18, 34}, Text: "CountdownToMars"}}
+	if diff := cmp.Diff(expected, tweet.Entities.Hashtags); diff != "" {
+		t.Error("Resulting hashtags does not match the sample", diff)
+	}
+	if len(tweet.Entities.Media) != 1 {
+		t.Errorf("Expected 1 media entity, got %d", len(tweet.Entities.Media))
+	}
+	if tweet.DisplayText != "Like for liftoff! #CountdownToMars" {
+		t.Errorf("Unexpected display text: %q", tweet.DisplayText)
+	}
+}
+
+func TestTweetVideoMetadata(t *testing.T) {
+	tweet, err := testScraper.GetTweet("1328684389388185600")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweet.Videos) != 1 {
+		t.Fatalf("Expected 1 video, got %d", len(tweet.Videos))
+	}
+	video := tweet.Videos[0]
+	if len(video.Variants) == 0 {
+		t.Error("Expected video variants")
+	}
+	if video.Duration == 0 {
+		t.Error("Expected video duration")
+	}
+	if video.Width == 0 || video.Height == 0 {
+		t.Errorf("Expected video dimensions, got %dx%d", video.Width, video.Height)
+	}
+}
+
+func TestTweetDetails(t *testing.T) {
+	tweet, err := testScraper.GetTweet("1328684389388185600")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.Lang != "en" {
+		t.Errorf("Expected tweet Lang=en, got %q", tweet.Lang)
+	}
+	if tweet.Source == "" || strings.Contains(tweet.Source, "<") {
+		t.Errorf("Expected client name in tweet Source, got %q", tweet.Source)
+	}
+	if tweet.QuoteCount == 0 {
+		t.Error("Expected tweet QuoteCount is not zero")
+	}
+	if tweet.ReplyPolicy != twitterscraper.ReplyEveryone {
+		t.Errorf("Expected tweet ReplyPolicy=%s, got %s", twitterscraper.ReplyEveryone, tweet.ReplyPolicy)
+	}
+}
+
+func TestTweetAuthor(t *testing.T) {
+	tweet, err := testScraper.GetTweet("1328684389388185600")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.Author == nil {
+		t.Fatal("Expected tweet Author is not nil")
+	}
+	if tweet.Author.Username != tweet.Username || tweet.Author.UserID != tweet.UserID {
+		t.Errorf("Expected author @%s (%s), got @%s (%s)", tweet.Username, tweet.UserID, tweet.Author.Username, tweet.Author.UserID)
+	}
+	if tweet.Author.FollowersCount == 0 {
+		t.Error("Expected author FollowersCount is not zero")
+	}
+}
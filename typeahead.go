@@ -0,0 +1,116 @@
+package twitterscraper
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+const (
+	typeaheadURL     = "https://twitter.com/i/api/1.1/search/typeahead.json"
+	savedSearchesURL = "https://twitter.com/i/api/1.1/saved_searches/list.json"
+)
+
+// Typeahead suggestions of the search box, ranked as on the site
+type Typeahead struct {
+	Hashtags []string
+	// SavedSearches of the logged in account
+	SavedSearches []string
+	Topics        []string
+	Users         []Profile
+}
+
+type typeaheadResult struct {
+	Hashtags []struct {
+		Hashtag string `json:"hashtag"`
+	} `json:"hashtags"`
+	Topics []struct {
+		Topic string `json:"topic"`
+	} `json:"topics"`
+	Users []typeaheadUser `json:"users"`
+}
+
+type typeaheadUser struct {
+	legacyUser
+	IsBlueVerified bool `json:"is_blue_verified"`
+}
+
+type savedSearch struct {
+	Query string `json:"query"`
+}
+
+// SearchTypeahead returns suggestions of users, hashtags and topics for the search prefix.
+// Saved searches are added for logged in account, if they can be fetched.
+// Recent searches are not available, the site keeps them in the browser.
+func (s *Scraper) SearchTypeahead(ctx context.Context, prefix string) (*Typeahead, error) {
+	req, err := http.NewRequest("GET", typeaheadURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	q := req.URL.Query()
+	q.Set("include_ext_is_blue_verified", "1")
+	q.Set("include_ext_verified_type", "1")
+	q.Set("q", prefix)
+	q.Set("src", "search_box")
+	q.Set("result_type", "events,users,topics,lists")
+	req.URL.RawQuery = q.Encode()
+
+	var jsn typeaheadResult
+	err = s.RequestAPI(req, &jsn)
+	if err != nil {
+		return nil, err
+	}
+
+	typeahead := jsn.parse()
+	// saved searches are best-effort, suggestions are returned without them
+	if s.isLogged && !s.isOpenAccount {
+		typeahead.SavedSearches = s.savedSearches(ctx, prefix)
+	}
+
+	return typeahead, nil
+}
+
+func (jsn *typeaheadResult) parse() *Typeahead {
+	typeahead := &Typeahead{}
+	for _, user := range jsn.Users {
+		profile := parseProfile(user.legacyUser)
+		profile.IsBlueVerified = profile.IsBlueVerified || user.IsBlueVerified
+		typeahead.Users = append(typeahead.Users, profile)
+	}
+	for _, hashtag := range jsn.Hashtags {
+		typeahead.Hashtags = append(typeahead.Hashtags, strings.TrimPrefix(hashtag.Hashtag, "#"))
+	}
+	for _, topic := range jsn.Topics {
+		if strings.HasPrefix(topic.Topic, "#") {
+			typeahead.Hashtags = append(typeahead.Hashtags, topic.Topic[1:])
+		} else {
+			typeahead.Topics = append(typeahead.Topics, topic.Topic)
+		}
+	}
+	return typeahead
+}
+
+func (s *Scraper) savedSearches(ctx context.Context, prefix string) []string {
+	req, err := http.NewRequest("GET", savedSearchesURL, nil)
+	if err != nil {
+		return nil
+	}
+	var saved []savedSearch
+	if err := s.RequestAPI(req.WithContext(ctx), &saved); err != nil {
+		return nil
+	}
+	return matchSavedSearches(saved, prefix)
+}
+
+// matchSavedSearches returns queries of saved searches starting with the prefix
+func matchSavedSearches(saved []savedSearch, prefix string) []string {
+	var queries []string
+	for _, search := range saved {
+		if strings.HasPrefix(strings.ToLower(search.Query), strings.ToLower(prefix)) {
+			queries = append(queries, search.Query)
+		}
+	}
+	return queries
+}
@@ -0,0 +1,45 @@
+package twitterscraper
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTypeahead(t *testing.T) {
+	var jsn typeaheadResult
+	loadFixture(t, "typeahead/result.json", &jsn)
+	typeahead := jsn.parse()
+
+	var users []string
+	for _, user := range typeahead.Users {
+		users = append(users, user.UserID+" "+user.Username)
+	}
+	if diff := cmp.Diff([]string{"783214 X", "1 TwitterDev", "2 someone"}, users); diff != "" {
+		t.Error("Resulting users do not match the sample", diff)
+	}
+	// blue verification is taken from extended and top-level fields
+	for i, expected := range []bool{true, true, false} {
+		if typeahead.Users[i].IsBlueVerified != expected {
+			t.Errorf("User %s: expected blue verified %v", typeahead.Users[i].Username, expected)
+		}
+	}
+	if !typeahead.Users[1].IsVerified {
+		t.Error("Expected TwitterDev is verified")
+	}
+	// hashtag topics are moved to hashtags
+	if diff := cmp.Diff([]string{"twitter", "twitterapi"}, typeahead.Hashtags); diff != "" {
+		t.Error("Resulting hashtags do not match the sample", diff)
+	}
+	if diff := cmp.Diff([]string{"twitter outage"}, typeahead.Topics); diff != "" {
+		t.Error("Resulting topics do not match the sample", diff)
+	}
+}
+
+func TestMatchSavedSearches(t *testing.T) {
+	saved := []savedSearch{{Query: "Twitter API"}, {Query: "golang"}, {Query: "twitter scraper"}}
+	queries := matchSavedSearches(saved, "twit")
+	if diff := cmp.Diff([]string{"Twitter API", "twitter scraper"}, queries); diff != "" {
+		t.Error("Resulting saved searches do not match the prefix", diff)
+	}
+}
@@ -0,0 +1,30 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSearchTypeahead(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	typeahead, err := testScraper.SearchTypeahead(context.Background(), "twitt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(typeahead.Users) == 0 {
+		t.Fatal("Expected user suggestions")
+	}
+	for _, user := range typeahead.Users {
+		if user.Username == "" || user.UserID == "" {
+			t.Errorf("Expected username and ID of suggested user, got %q %q", user.Username, user.UserID)
+		}
+	}
+	for _, hashtag := range typeahead.Hashtags {
+		if strings.HasPrefix(hashtag, "#") {
+			t.Errorf("Expected hashtag without #, got %q", hashtag)
+		}
+	}
+}
@@ -12,59 +12,199 @@ type (
 
 	// Photo type.
 	Photo struct {
-		ID  string
-		URL string
+		AltText   string
+		Height    int
+		ID        string
+		Sensitive MediaSensitivity
+		URL       string
+		Width     int
 	}
 
 	// Video type.
 	Video struct {
-		ID      string
-		Preview string
-		URL     string
+		AltText   string
+		Duration  time.Duration
+		Height    int
+		HLSURL    string
+		ID        string
+		Preview   string
+		Sensitive MediaSensitivity
+		URL       string
+		Variants  []Variant
+		ViewCount int
+		Width     int
 	}
 
 	// GIF type.
 	GIF struct {
-		ID      string
-		Preview string
-		URL     string
+		AltText   string
+		Height    int
+		ID        string
+		Preview   string
+		Sensitive MediaSensitivity
+		URL       string
+		Variants  []Variant
+		Width     int
+	}
+
+	// Variant of video or GIF encoding.
+	Variant struct {
+		Bitrate     int
+		ContentType string
+		URL         string
+	}
+
+	// MediaSensitivity categories of the sensitive media warning.
+	MediaSensitivity struct {
+		AdultContent    bool
+		GraphicViolence bool
+		Other           bool
+	}
+
+	// Poll type.
+	Poll struct {
+		Choices        []PollChoice
+		CountsAreFinal bool
+		EndTime        time.Time
+		TotalVotes     int
+	}
+
+	// PollChoice type.
+	PollChoice struct {
+		Label string
+		Count int
+	}
+
+	// Card type for link preview (summary, player, etc).
+	Card struct {
+		Description string
+		Domain      string
+		Name        string
+		PlayerURL   string
+		Thumbnail   string
+		Title       string
+		URL         string
+	}
+
+	// CommunityNote (Birdwatch) type.
+	CommunityNote struct {
+		Entities []CommunityNoteEntity
+		ID       string
+		Status   NoteStatus
+		Text     string
+		URLs     []string
+	}
+
+	// CommunityNoteEntity is a link in the text of community note.
+	CommunityNoteEntity struct {
+		FromIndex int
+		ToIndex   int
+		URL       string
+	}
+
+	// TweetEntities of the tweet text.
+	// Indices are offsets in UTF-16 code units of the unescaped tweet text.
+	TweetEntities struct {
+		Cashtags []HashtagEntity
+		Hashtags []HashtagEntity
+		Media    []MediaEntity
+		Mentions []MentionEntity
+		URLs     []URLEntity
+	}
+
+	// HashtagEntity type for hashtags and cashtags.
+	HashtagEntity struct {
+		Indices [2]int
+		Text    string
+	}
+
+	// MediaEntity type.
+	MediaEntity struct {
+		DisplayURL  string
+		ExpandedURL string
+		ID          string
+		Indices     [2]int
+		MediaURL    string
+		Type        string
+		URL         string
+	}
+
+	// MentionEntity type.
+	MentionEntity struct {
+		ID       string
+		Indices  [2]int
+		Name     string
+		Username string
+	}
+
+	// URLEntity type.
+	URLEntity struct {
+		DisplayURL  string
+		ExpandedURL string
+		Indices     [2]int
+		URL         string
 	}
 
 	// Tweet type.
 	Tweet struct {
+		Author            *Profile
+		BookmarkCount     int
+		Card              *Card
+		CommunityID       string
+		CommunityNote     *CommunityNote
 		ConversationID    string
+		DisplayText       string
+		DisplayTextRange  [2]int
+		EditableUntil     time.Time
+		EditHistoryIDs    []string
+		EditsRemaining    int
+		Entities          TweetEntities
 		GIFs              []GIF
 		Hashtags          []string
 		HTML              string
 		ID                string
 		InReplyToStatus   *Tweet
 		InReplyToStatusID string
+		InReplyToUserID   string
+		InReplyToUsername string
+		IsEditEligible    bool
 		IsQuoted          bool
 		IsPin             bool
 		IsReply           bool
 		IsRetweet         bool
 		IsSelfThread      bool
+		IsTranslatable    bool
+		Lang              string
 		Likes             int
+		LimitedActions    []string
 		Name              string
 		Mentions          []Mention
 		PermanentURL      string
 		Photos            []Photo
 		Place             *Place
+		Poll              *Poll
+		PossiblySensitive bool
+		QuoteCount        int
 		QuotedStatus      *Tweet
 		QuotedStatusID    string
 		Replies           int
+		ReplyPolicy       ReplyPolicy
 		Retweets          int
 		RetweetedStatus   *Tweet
 		RetweetedStatusID string
+		Source            string
+		SpaceID           string
 		Text              string
 		Thread            []*Tweet
 		TimeParsed        time.Time
 		Timestamp         int64
+		Unavailable       UnavailableReason
 		URLs              []string
 		UserID            string
 		Username          string
 		Videos            []Video
 		Views             int
+		VisibilityLabel   string
 		SensitiveContent  bool
 	}
 
@@ -74,6 +214,12 @@ type (
 		Error error
 	}
 
+	// ListResult of scrapping.
+	ListResult struct {
+		List
+		Error error
+	}
+
 	// TweetResult of scrapping.
 	TweetResult struct {
 		Tweet
@@ -81,51 +227,40 @@ type (
 	}
 
 	legacyTweet struct {
-		ConversationIDStr string `json:"conversation_id_str"`
-		CreatedAt         string `json:"created_at"`
-		FavoriteCount     int    `json:"favorite_count"`
-		FullText          string `json:"full_text"`
-		Entities          struct {
-			Hashtags []struct {
-				Text string `json:"text"`
-			} `json:"hashtags"`
-			Media []struct {
-				MediaURLHttps string `json:"media_url_https"`
-				Type          string `json:"type"`
-				URL           string `json:"url"`
-			} `json:"media"`
-			URLs []struct {
-				ExpandedURL string `json:"expanded_url"`
-				URL         string `json:"url"`
-			} `json:"urls"`
-			UserMentions []struct {
-				IDStr      string `json:"id_str"`
-				Name       string `json:"name"`
-				ScreenName string `json:"screen_name"`
-			} `json:"user_mentions"`
-		} `json:"entities"`
-		ExtendedEntities struct {
-			Media []struct {
-				IDStr                    string `json:"id_str"`
-				MediaURLHttps            string `json:"media_url_https"`
-				ExtSensitiveMediaWarning struct {
-					AdultContent    bool `json:"adult_content"`
-					GraphicViolence bool `json:"graphic_violence"`
-					Other           bool `json:"other"`
-				} `json:"ext_sensitive_media_warning"`
-				Type      string `json:"type"`
-				URL       string `json:"url"`
-				VideoInfo struct {
-					Variants []struct {
-						Bitrate int    `json:"bitrate"`
-						URL     string `json:"url"`
-					} `json:"variants"`
-				} `json:"video_info"`
-			} `json:"media"`
+		BookmarkCount       int        `json:"bookmark_count"`
+		Card                legacyCard `json:"card"`
+		ConversationControl struct {
+			Policy string `json:"policy"`
+		} `json:"conversation_control"`
+		ConversationIDStr string         `json:"conversation_id_str"`
+		CreatedAt         string         `json:"created_at"`
+		FavoriteCount     int            `json:"favorite_count"`
+		FullText          string         `json:"full_text"`
+		DisplayTextRange  []int          `json:"display_text_range"`
+		Entities          legacyEntities `json:"entities"`
+		ExtendedEntities  struct {
+			Media []legacyMedia `json:"media"`
 		} `json:"extended_entities"`
+		Ext struct {
+			BirdwatchPivot struct {
+				R struct {
+					Ok birdwatchPivot `json:"ok"`
+				} `json:"r"`
+			} `json:"birdwatchPivot"`
+			EditControl struct {
+				R struct {
+					Ok legacyEditControl `json:"ok"`
+				} `json:"r"`
+			} `json:"editControl"`
+		} `json:"ext"`
 		IDStr                 string `json:"id_str"`
 		InReplyToStatusIDStr  string `json:"in_reply_to_status_id_str"`
+		InReplyToScreenName   string `json:"in_reply_to_screen_name"`
+		InReplyToUserIDStr    string `json:"in_reply_to_user_id_str"`
+		IsTranslatable        bool   `json:"is_translatable"`
+		Lang                  string `json:"lang"`
 		Place                 Place  `json:"place"`
+		PossiblySensitive     bool   `json:"possibly_sensitive"`
 		ReplyCount            int    `json:"reply_count"`
 		RetweetCount          int    `json:"retweet_count"`
 		RetweetedStatusIDStr  string `json:"retweeted_status_id_str"`
@@ -133,9 +268,11 @@ type (
 			Result *result `json:"result"`
 		} `json:"retweeted_status_result"`
 		QuotedStatusIDStr string `json:"quoted_status_id_str"`
+		QuoteCount        int    `json:"quote_count"`
 		SelfThread        struct {
 			IDStr string `json:"id_str"`
 		} `json:"self_thread"`
+		Source    string    `json:"source"`
 		Time      time.Time `json:"time"`
 		UserIDStr string    `json:"user_id_str"`
 		Views     struct {
@@ -144,6 +281,34 @@ type (
 		} `json:"ext_views"`
 	}
 
+	legacyEntities struct {
+		Hashtags []struct {
+			Indices [2]int `json:"indices"`
+			Text    string `json:"text"`
+		} `json:"hashtags"`
+		Media []struct {
+			MediaURLHttps string `json:"media_url_https"`
+			Type          string `json:"type"`
+			URL           string `json:"url"`
+		} `json:"media"`
+		Symbols []struct {
+			Indices [2]int `json:"indices"`
+			Text    string `json:"text"`
+		} `json:"symbols"`
+		URLs []struct {
+			DisplayURL  string `json:"display_url"`
+			ExpandedURL string `json:"expanded_url"`
+			Indices     [2]int `json:"indices"`
+			URL         string `json:"url"`
+		} `json:"urls"`
+		UserMentions []struct {
+			IDStr      string `json:"id_str"`
+			Indices    [2]int `json:"indices"`
+			Name       string `json:"name"`
+			ScreenName string `json:"screen_name"`
+		} `json:"user_mentions"`
+	}
+
 	legacyUser struct {
 		CreatedAt   string `json:"created_at"`
 		Description string `json:"description"`
@@ -154,6 +319,7 @@ type (
 				} `json:"urls"`
 			} `json:"url"`
 		} `json:"entities"`
+		ExtIsBlueVerified    bool     `json:"ext_is_blue_verified"`
 		FavouritesCount      int      `json:"favourites_count"`
 		FollowersCount       int      `json:"followers_count"`
 		FriendsCount         int      `json:"friends_count"`
@@ -183,6 +349,7 @@ type (
 		} `json:"bounding_box"`
 	}
 
+	fetchListFunc    func(query string, maxListsNbr int, cursor string) ([]*List, string, error)
 	fetchProfileFunc func(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error)
 	fetchTweetFunc   func(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error)
 )
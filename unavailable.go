@@ -0,0 +1,64 @@
+package twitterscraper
+
+import "strings"
+
+// UnavailableReason explains why the tweet content is not available
+type UnavailableReason string
+
+const (
+	// UnavailableDeleted - tweet was deleted by the author
+	UnavailableDeleted UnavailableReason = "deleted"
+	// UnavailableSuspended - author account is suspended
+	UnavailableSuspended UnavailableReason = "suspended"
+	// UnavailableWithheld - tweet is withheld in the country
+	UnavailableWithheld UnavailableReason = "withheld"
+	// UnavailableAgeRestricted - tweet has age-restricted adult content
+	UnavailableAgeRestricted UnavailableReason = "age_restricted"
+	// UnavailableProtected - author account is protected
+	UnavailableProtected UnavailableReason = "protected"
+	// UnavailableUnknown - tweet is unavailable for other reason
+	UnavailableUnknown UnavailableReason = "unavailable"
+)
+
+// parseUnavailableReason maps reason of TweetUnavailable or text of TweetTombstone
+func parseUnavailableReason(reason string, text string) UnavailableReason {
+	switch reason {
+	case "Suspended":
+		return UnavailableSuspended
+	case "Protected":
+		return UnavailableProtected
+	case "NsfwLoggedOut", "NsfwViewerIsUnderage", "NsfwViewerHasNoStatedAge":
+		return UnavailableAgeRestricted
+	case "Withheld":
+		return UnavailableWithheld
+	case "Deleted":
+		return UnavailableDeleted
+	}
+	text = strings.ToLower(text)
+	switch {
+	case strings.Contains(text, "deleted"):
+		return UnavailableDeleted
+	case strings.Contains(text, "suspended"):
+		return UnavailableSuspended
+	case strings.Contains(text, "withheld"), strings.Contains(text, "in your country"):
+		return UnavailableWithheld
+	case strings.Contains(text, "age-restricted"), strings.Contains(text, "adult content"):
+		return UnavailableAgeRestricted
+	case strings.Contains(text, "protected"):
+		return UnavailableProtected
+	}
+	return UnavailableUnknown
+}
+
+// setUnavailableID sets ID of placeholder, its time is decoded from the ID
+func (tw *Tweet) setUnavailableID(id string) {
+	tw.ID = id
+	if id == "" {
+		return
+	}
+	tw.PermanentURL = "https://twitter.com/i/web/status/" + id
+	if tm, err := TweetIDTime(id); err == nil {
+		tw.TimeParsed = tm
+		tw.Timestamp = tm.Unix()
+	}
+}
@@ -0,0 +1,77 @@
+package twitterscraper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseUnavailableTweets(t *testing.T) {
+	var conversation threadedConversation
+	loadFixture(t, "unavailable/conversation.json", &conversation)
+	tweets := conversation.parse()
+	if len(tweets) != 3 {
+		t.Fatalf("Expected 3 tweets, got %d", len(tweets))
+	}
+	created := time.Date(2023, 9, 8, 4, 15, 59, 129000000, time.UTC)
+
+	deleted := tweets[0]
+	if deleted.ID != "1700000000000000005" || deleted.Unavailable != UnavailableDeleted {
+		t.Errorf("Expected deleted tombstone 1700000000000000005, got %s %q", deleted.ID, deleted.Unavailable)
+	}
+	if deleted.PermanentURL != "https://twitter.com/i/web/status/1700000000000000005" {
+		t.Errorf("Unexpected PermanentURL %s", deleted.PermanentURL)
+	}
+	if !deleted.TimeParsed.Equal(created) || deleted.Timestamp != created.Unix() {
+		t.Errorf("Expected time from snowflake ID %s, got %s", created, deleted.TimeParsed)
+	}
+
+	suspended := tweets[1]
+	if suspended.ID != "1700000000000000006" || suspended.Unavailable != UnavailableSuspended {
+		t.Errorf("Expected suspended tweet 1700000000000000006, got %s %q", suspended.ID, suspended.Unavailable)
+	}
+
+	limited := tweets[2]
+	if limited.ID != "1700000000000000007" || limited.Unavailable != "" {
+		t.Errorf("Expected available tweet 1700000000000000007, got %s %q", limited.ID, limited.Unavailable)
+	}
+	if len(limited.LimitedActions) != 2 || limited.LimitedActions[0] != "Reply" || limited.LimitedActions[1] != "Retweet" {
+		t.Errorf("Unexpected LimitedActions %v", limited.LimitedActions)
+	}
+	if limited.VisibilityLabel != "Replies are limited for this post" {
+		t.Errorf("Unexpected VisibilityLabel %q", limited.VisibilityLabel)
+	}
+	quoted := limited.QuotedStatus
+	if quoted == nil || quoted.ID != "1700000000000000008" || quoted.Unavailable != UnavailableSuspended {
+		t.Errorf("Expected suspended quoted tweet 1700000000000000008, got %+v", quoted)
+	}
+	if quoted != nil && quoted.TimeParsed.IsZero() {
+		t.Error("Expected quoted placeholder time from snowflake ID")
+	}
+}
+
+func TestParseUnavailableReason(t *testing.T) {
+	tests := []struct {
+		reason string
+		text   string
+		want   UnavailableReason
+	}{
+		{"Suspended", "", UnavailableSuspended},
+		{"Protected", "", UnavailableProtected},
+		{"NsfwLoggedOut", "", UnavailableAgeRestricted},
+		{"NsfwViewerIsUnderage", "", UnavailableAgeRestricted},
+		{"Withheld", "", UnavailableWithheld},
+		{"Deleted", "", UnavailableDeleted},
+		{"", "This Post was deleted by the Post author. Learn more", UnavailableDeleted},
+		{"", "This Post is from a suspended account. Learn more", UnavailableSuspended},
+		{"", "This Post has been withheld in your country in response to a legal demand.", UnavailableWithheld},
+		{"", "Age-restricted adult content. This content might not be appropriate for people under 18 years old.", UnavailableAgeRestricted},
+		{"", "You're unable to view this Post because this account owner limits who can view their Posts. Protected", UnavailableProtected},
+		{"", "This Post is unavailable.", UnavailableUnknown},
+		{"Other", "", UnavailableUnknown},
+	}
+	for _, test := range tests {
+		if got := parseUnavailableReason(test.reason, test.text); got != test.want {
+			t.Errorf("parseUnavailableReason(%q, %q) = %q, want %q", test.reason, test.text, got, test.want)
+		}
+	}
+}
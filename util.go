@@ -13,10 +13,8 @@ import (
 )
 
 var (
-	reHashtag    = regexp.MustCompile(`\B(\#\S+\b)`)
-	reTwitterURL = regexp.MustCompile(`https:(\/\/t\.co\/([A-Za-z0-9]|[A-Za-z]){10})`)
-	reUsername   = regexp.MustCompile(`\B(\@\S{1,15}\b)`)
-	twURL        = urlParse("https://twitter.com")
+	reSpaceURL = regexp.MustCompile(`^https?://(?:(?:www|mobile)\.)?(?:twitter|x)\.com/i/spaces/([A-Za-z0-9]+)`)
+	twURL      = urlParse("https://twitter.com")
 )
 
 func (s *Scraper) newRequest(method string, url string) (*http.Request, error) {
@@ -66,27 +64,16 @@ func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetc
 	channel := make(chan *ProfileResult)
 	go func(query string) {
 		defer close(channel)
-		var nextCursor string
+		paginator := NewProfilePaginator(query, fetchFunc).WithPageSize(maxProfilesNbr)
 		profilesNbr := 0
-		for profilesNbr < maxProfilesNbr {
-			select {
-			case <-ctx.Done():
-				channel <- &ProfileResult{Error: ctx.Err()}
-				return
-			default:
-			}
-
-			profiles, next, err := fetchFunc(query, maxProfilesNbr, nextCursor)
+		for profilesNbr < maxProfilesNbr && paginator.HasNext() {
+			page, err := paginator.Next(ctx)
 			if err != nil {
 				channel <- &ProfileResult{Error: err}
 				return
 			}
 
-			if len(profiles) == 0 {
-				break
-			}
-
-			for _, profile := range profiles {
+			for _, profile := range page.Profiles {
 				select {
 				case <-ctx.Done():
 					channel <- &ProfileResult{Error: ctx.Err()}
@@ -95,7 +82,6 @@ func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetc
 				}
 
 				if profilesNbr < maxProfilesNbr {
-					nextCursor = next
 					channel <- &ProfileResult{Profile: *profile}
 				} else {
 					break
@@ -107,31 +93,61 @@ func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetc
 	return channel
 }
 
-func getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetchFunc fetchTweetFunc) <-chan *TweetResult {
-	channel := make(chan *TweetResult)
+func getListTimeline(ctx context.Context, query string, maxListsNbr int, fetchFunc fetchListFunc) <-chan *ListResult {
+	channel := make(chan *ListResult)
 	go func(query string) {
 		defer close(channel)
-		var nextCursor string
-		tweetsNbr := 0
-		for tweetsNbr < maxTweetsNbr {
-			select {
-			case <-ctx.Done():
-				channel <- &TweetResult{Error: ctx.Err()}
+		paginator := NewListPaginator(query, fetchFunc).WithPageSize(maxListsNbr)
+		listsNbr := 0
+		for listsNbr < maxListsNbr && paginator.HasNext() {
+			page, err := paginator.Next(ctx)
+			if err != nil {
+				channel <- &ListResult{Error: err}
 				return
-			default:
 			}
 
-			tweets, next, err := fetchFunc(query, maxTweetsNbr, nextCursor)
+			for _, list := range page.Lists {
+				select {
+				case <-ctx.Done():
+					channel <- &ListResult{Error: ctx.Err()}
+					return
+				default:
+				}
+
+				if listsNbr < maxListsNbr {
+					channel <- &ListResult{List: *list}
+				} else {
+					break
+				}
+				listsNbr++
+			}
+		}
+	}(query)
+	return channel
+}
+
+func getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, filter timelineFilter, fetchFunc fetchTweetFunc) <-chan *TweetResult {
+	channel := make(chan *TweetResult)
+	go func(query string) {
+		defer close(channel)
+		paginator := NewTweetPaginator(query, fetchFunc).WithPageSize(maxTweetsNbr)
+		tweetsNbr := 0
+		for tweetsNbr < maxTweetsNbr && paginator.HasNext() {
+			page, err := paginator.Next(ctx)
 			if err != nil {
 				channel <- &TweetResult{Error: err}
 				return
 			}
 
-			if len(tweets) == 0 {
-				break
-			}
+			for _, tweet := range page.Tweets {
+				keep, stop := filter.match(tweet)
+				if stop {
+					return
+				}
+				if !keep {
+					continue
+				}
 
-			for _, tweet := range tweets {
 				select {
 				case <-ctx.Done():
 					channel <- &TweetResult{Error: ctx.Err()}
@@ -140,7 +156,6 @@ func getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetch
 				}
 
 				if tweetsNbr < maxTweetsNbr {
-					nextCursor = next
 					channel <- &TweetResult{Tweet: *tweet}
 				} else {
 					break
@@ -182,6 +197,8 @@ func parseLegacyTweet(user *legacyUser, tweet *legacyTweet) *Tweet {
 		tw.Place = &tweet.Place
 	}
 
+	parseTweetDetails(tw, tweet)
+
 	if tweet.QuotedStatusIDStr != "" {
 		tw.IsQuoted = true
 		tw.QuotedStatusID = tweet.QuotedStatusIDStr
@@ -194,8 +211,13 @@ func parseLegacyTweet(user *legacyUser, tweet *legacyTweet) *Tweet {
 		tw.IsRetweet = true
 		tw.RetweetedStatusID = tweet.RetweetedStatusIDStr
 		if tweet.RetweetedStatusResult.Result != nil {
-			tw.RetweetedStatus = parseLegacyTweet(&tweet.RetweetedStatusResult.Result.Core.UserResults.Result.Legacy, &tweet.RetweetedStatusResult.Result.Legacy)
-			tw.RetweetedStatusID = tw.RetweetedStatus.ID
+			tw.RetweetedStatus = tweet.RetweetedStatusResult.Result.parse()
+			if tw.RetweetedStatus != nil {
+				if tw.RetweetedStatus.ID == "" {
+					tw.RetweetedStatus.setUnavailableID(tw.RetweetedStatusID)
+				}
+				tw.RetweetedStatusID = tw.RetweetedStatus.ID
+			}
 		}
 	}
 
@@ -226,111 +248,14 @@ func parseLegacyTweet(user *legacyUser, tweet *legacyTweet) *Tweet {
 		})
 	}
 
-	for _, media := range tweet.ExtendedEntities.Media {
-		if media.Type == "photo" {
-			photo := Photo{
-				ID:  media.IDStr,
-				URL: media.MediaURLHttps,
-			}
-
-			tw.Photos = append(tw.Photos, photo)
-		} else if media.Type == "video" {
-			video := Video{
-				ID:      media.IDStr,
-				Preview: media.MediaURLHttps,
-			}
-
-			maxBitrate := 0
-			for _, variant := range media.VideoInfo.Variants {
-				if variant.Bitrate > maxBitrate {
-					video.URL = strings.TrimSuffix(variant.URL, "?tag=10")
-					maxBitrate = variant.Bitrate
-				}
-			}
-
-			tw.Videos = append(tw.Videos, video)
-		} else if media.Type == "animated_gif" {
-			gif := GIF{
-				ID:      media.IDStr,
-				Preview: media.MediaURLHttps,
-			}
-
-			// Twitter's API doesn't provide bitrate for GIFs, (it's always set to zero).
-			// Therefore we check for `>=` instead of `>` in the loop below.
-			// Also, GIFs have just a single variant today. Just in case that changes in the future,
-			// and there will be multiple variants, we'll pick the one with the highest bitrate,
-			// if other one will have a non-zero bitrate.
-			maxBitrate := 0
-			for _, variant := range media.VideoInfo.Variants {
-				if variant.Bitrate >= maxBitrate {
-					gif.URL = variant.URL
-					maxBitrate = variant.Bitrate
-				}
-			}
-
-			tw.GIFs = append(tw.GIFs, gif)
-		}
-
-		if !tw.SensitiveContent {
-			sensitive := media.ExtSensitiveMediaWarning
-			tw.SensitiveContent = sensitive.AdultContent || sensitive.GraphicViolence || sensitive.Other
-		}
-	}
+	parseMedia(tw, tweet)
 
 	for _, url := range tweet.Entities.URLs {
 		tw.URLs = append(tw.URLs, url.ExpandedURL)
 	}
+	tw.SpaceID = parseSpaceID(tw.URLs)
 
-	tw.HTML = tweet.FullText
-	tw.HTML = reHashtag.ReplaceAllStringFunc(tw.HTML, func(hashtag string) string {
-		return fmt.Sprintf(`<a href="https://twitter.com/hashtag/%s">%s</a>`,
-			strings.TrimPrefix(hashtag, "#"),
-			hashtag,
-		)
-	})
-	tw.HTML = reUsername.ReplaceAllStringFunc(tw.HTML, func(username string) string {
-		return fmt.Sprintf(`<a href="https://twitter.com/%s">%s</a>`,
-			strings.TrimPrefix(username, "@"),
-			username,
-		)
-	})
-	var foundedMedia []string
-	tw.HTML = reTwitterURL.ReplaceAllStringFunc(tw.HTML, func(tco string) string {
-		for _, entity := range tweet.Entities.URLs {
-			if tco == entity.URL {
-				return fmt.Sprintf(`<a href="%s">%s</a>`, entity.ExpandedURL, tco)
-			}
-		}
-		for _, entity := range tweet.ExtendedEntities.Media {
-			if tco == entity.URL {
-				foundedMedia = append(foundedMedia, entity.MediaURLHttps)
-				return fmt.Sprintf(`<br><a href="%s"><img src="%s"/></a>`, tco, entity.MediaURLHttps)
-			}
-		}
-		return tco
-	})
-	for _, photo := range tw.Photos {
-		url := photo.URL
-		if stringInSlice(url, foundedMedia) {
-			continue
-		}
-		tw.HTML += fmt.Sprintf(`<br><img src="%s"/>`, url)
-	}
-	for _, video := range tw.Videos {
-		url := video.Preview
-		if stringInSlice(url, foundedMedia) {
-			continue
-		}
-		tw.HTML += fmt.Sprintf(`<br><img src="%s"/>`, url)
-	}
-	for _, gif := range tw.GIFs {
-		url := gif.Preview
-		if stringInSlice(url, foundedMedia) {
-			continue
-		}
-		tw.HTML += fmt.Sprintf(`<br><img src="%s"/>`, url)
-	}
-	tw.HTML = strings.Replace(tw.HTML, "\n", "<br>", -1)
+	parseEntities(tw, tweet)
 	return tw
 }
 
@@ -342,6 +267,7 @@ func parseProfile(user legacyUser) Profile {
 		FollowersCount: user.FollowersCount,
 		FollowingCount: user.FavouritesCount,
 		FriendsCount:   user.FriendsCount,
+		IsBlueVerified: user.ExtIsBlueVerified,
 		IsPrivate:      user.Protected,
 		IsVerified:     user.Verified,
 		LikesCount:     user.FavouritesCount,
@@ -368,6 +294,56 @@ func parseProfile(user legacyUser) Profile {
 	return profile
 }
 
+// parseSpaceID returns ID of the first linked space
+func parseSpaceID(urls []string) string {
+	for _, u := range urls {
+		if match := reSpaceURL.FindStringSubmatch(u); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// msecTimestamp is unix time in milliseconds, encoded as number or string
+type msecTimestamp int64
+
+func (ts *msecTimestamp) UnmarshalJSON(data []byte) error {
+	str := strings.Trim(string(data), `"`)
+	if str == "" || str == "null" {
+		return nil
+	}
+	msec, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return err
+	}
+	*ts = msecTimestamp(msec)
+	return nil
+}
+
+// Time returns timestamp as UTC time, zero time if timestamp is not set
+func (ts msecTimestamp) Time() time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(ts)*int64(time.Millisecond)).UTC()
+}
+
+// flexInt is integer encoded as number or string
+type flexInt int
+
+func (i *flexInt) UnmarshalJSON(data []byte) error {
+	str := strings.Trim(string(data), `"`)
+	if str == "" || str == "null" {
+		return nil
+	}
+	n, err := strconv.Atoi(str)
+	if err != nil {
+		return err
+	}
+	*i = flexInt(n)
+	return nil
+}
+
 func mapToJSONString(data map[string]interface{}) string {
 	jsonBytes, err := json.Marshal(data)
 	if err != nil {